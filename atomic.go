@@ -0,0 +1,192 @@
+package gocsv
+
+import (
+	"reflect"
+)
+
+// WithAtomicDecode makes Decode leave its target untouched when it returns an error. The record
+// is decoded into a copy of the target, which replaces the target once decoding succeeds. Hooks
+// are called with the copy.
+func (dec *Decoder) WithAtomicDecode() *Decoder {
+	dec.atomic = true
+	return dec
+}
+
+// WithZeroBeforeDecode makes Decode reset its target to the zero value, or empty it if it is a
+// map, before decoding, so that no field keeps a value from a previous record.
+func (dec *Decoder) WithZeroBeforeDecode() *Decoder {
+	dec.zero = true
+	return dec
+}
+
+// target returns the value to decode a record into in place of v, along with a function that
+// stores it in v once decoding succeeds.
+func (dec *Decoder) target(v interface{}) (interface{}, func()) {
+	rv := reflect.ValueOf(v)
+
+	switch {
+	case rv.Kind() == reflect.Map && !rv.IsNil():
+		if !dec.atomic {
+			if dec.zero {
+				rv.Clear()
+			}
+
+			return v, func() {}
+		}
+
+		s := reflect.MakeMap(rv.Type())
+		if !dec.zero {
+			copyMap(s, rv)
+		}
+
+		return s.Interface(), func() {
+			rv.Clear()
+			copyMap(rv, s)
+		}
+	case rv.Kind() == reflect.Ptr && !rv.IsNil():
+		if !dec.atomic {
+			if dec.zero {
+				rv.Elem().Set(reflect.Zero(rv.Type().Elem()))
+			}
+
+			return v, func() {}
+		}
+
+		s := reflect.New(rv.Type().Elem())
+		if !dec.zero {
+			s.Elem().Set(rv.Elem())
+			dec.detach(s.Elem())
+		}
+
+		return s.Interface(), func() {
+			rv.Elem().Set(s.Elem())
+		}
+	}
+
+	return v, func() {}
+}
+
+// detach gives v its own copies of the pointers to nested structs that decoding writes through,
+// and of the maps, slices, and pointers held by the fields it decodes into, so that decoding into
+// v leaves the values it shares with the original untouched.
+func (dec *Decoder) detach(v reflect.Value) {
+	clones := map[clonedPointer]reflect.Value{}
+
+	if v.Kind() != reflect.Struct {
+		v.Set(clone(v, clones))
+		return
+	}
+
+	fields, err := dec.fields.fieldsOf(v.Type())
+	if err != nil {
+		return
+	}
+
+	copied := map[uintptr]bool{}
+
+fields:
+	for _, f := range fields {
+		fv := v
+		for i, x := range f.index {
+			if i > 0 && fv.Kind() == reflect.Ptr {
+				if fv.IsNil() {
+					continue fields
+				}
+
+				if !copied[fv.Pointer()] {
+					p := reflect.New(fv.Type().Elem())
+					p.Elem().Set(fv.Elem())
+					fv.Set(p)
+					copied[p.Pointer()] = true
+				}
+
+				fv = fv.Elem()
+			}
+
+			fv = fv.Field(x)
+		}
+
+		fv.Set(clone(fv, clones))
+	}
+}
+
+// clonedPointer identifies a pointer copied by clone. The type is part of it because a pointer
+// to a struct and a pointer to its first field hold the same address.
+type clonedPointer struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// clone returns a deep copy of v, following maps, slices, arrays, pointers, and the exported
+// fields of structs. clones holds the copies made of each pointer, so that a pointer reached
+// more than once, including through a cycle, is copied once.
+func clone(v reflect.Value, clones map[clonedPointer]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), clone(iter.Value(), clones))
+		}
+
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(clone(v.Index(i), clones))
+		}
+
+		return c
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+
+		key := clonedPointer{typ: v.Type(), ptr: v.Pointer()}
+		if c, ok := clones[key]; ok {
+			return c
+		}
+
+		c := reflect.New(v.Type().Elem())
+		clones[key] = c
+		c.Elem().Set(clone(v.Elem(), clones))
+
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(clone(v.Index(i), clones))
+		}
+
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+
+		for i := 0; i < c.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(clone(c.Field(i), clones))
+			}
+		}
+
+		return c
+	}
+
+	return v
+}
+
+// copyMap copies every entry of the map src into the map dst.
+func copyMap(dst, src reflect.Value) {
+	iter := src.MapRange()
+	for iter.Next() {
+		dst.SetMapIndex(iter.Key(), iter.Value())
+	}
+}
@@ -0,0 +1,144 @@
+package gocsv_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/rickbassham/gocsv"
+)
+
+func TestDecoder_WithAtomicDecode(t *testing.T) {
+	data := strings.NewReader("x,1,\"{\"\"k\"\":\"\"v\"\"}\",y\nz,bad,\"{\"\"k\"\":\"\"w\"\"}\",q")
+	r := csv.NewReader(data)
+	m := gocsv.Map[atomicTest]().KeepTags().Column("c", "Inner.C")
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "d", "c"}).WithMapping(m).WithAtomicDecode()
+
+	inner := &atomicInner{}
+	testVal := &atomicTest{Inner: inner}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A != "x" || testVal.B != 1 || testVal.D["k"] != "v" || testVal.Inner.C != "y" {
+		t.Errorf("testVal expected {x 1 map[k:v] y} but got %v %v", *testVal, *testVal.Inner)
+	}
+
+	if inner.C != "" {
+		t.Errorf("inner.C expected to be untouched but got %s", inner.C)
+	}
+
+	d := testVal.D
+	err = dec.Decode(testVal)
+	if err == nil {
+		t.Error("expected error")
+		return
+	}
+
+	if testVal.A != "x" || testVal.B != 1 || testVal.D["k"] != "v" || testVal.Inner.C != "y" {
+		t.Errorf("testVal expected {x 1 map[k:v] y} but got %v %v", *testVal, *testVal.Inner)
+	}
+
+	if d["k"] != "v" {
+		t.Errorf("d expected map[k:v] but got %v", d)
+	}
+}
+
+func TestDecoder_WithAtomicDecodeMap(t *testing.T) {
+	data := strings.NewReader("1,bad")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"}).WithAtomicDecode()
+
+	testVal := map[string]int{"a": 5}
+
+	err := dec.Decode(&testVal)
+	if err == nil {
+		t.Error("expected error")
+		return
+	}
+
+	if len(testVal) != 1 || testVal["a"] != 5 {
+		t.Errorf("testVal expected map[a:5] but got %v", testVal)
+	}
+}
+
+func TestDecoder_WithZeroBeforeDecode(t *testing.T) {
+	data := strings.NewReader("x")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a"}).WithAllowMissingColumns().WithZeroBeforeDecode()
+
+	testVal := &atomicTest{A: "a", B: 7}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A != "x" {
+		t.Errorf("testVal.A expected x but got %s", testVal.A)
+	}
+
+	if testVal.B != 0 {
+		t.Errorf("testVal.B expected 0 but got %d", testVal.B)
+	}
+}
+
+func TestDecoder_WithAtomicDecodeAndZero(t *testing.T) {
+	data := strings.NewReader("x,1\ny,bad")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"}).WithAllowMissingColumns().WithAtomicDecode().WithZeroBeforeDecode()
+
+	testVal := &atomicTest{D: map[string]string{"k": "v"}}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A != "x" || testVal.B != 1 || testVal.D != nil {
+		t.Errorf("testVal expected {x 1 map[]} but got %v", *testVal)
+	}
+
+	err = dec.Decode(testVal)
+	if err == nil {
+		t.Error("expected error")
+		return
+	}
+
+	if testVal.A != "x" || testVal.B != 1 {
+		t.Errorf("testVal expected {x 1} but got %v", *testVal)
+	}
+}
+
+func TestDecoder_WithAtomicDecodeJSON(t *testing.T) {
+	data := strings.NewReader("\"[9,9,9]\",\"{\"\"k\"\":7}\",x")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"c", "p", "n"}).WithAtomicDecode()
+
+	testVal := &atomicJSONTest{C: []int{1, 2, 3}, P: &atomicJSON{K: 1}, N: 2}
+	c := testVal.C
+	p := testVal.P
+
+	err := dec.Decode(testVal)
+	if err == nil {
+		t.Error("expected error")
+		return
+	}
+
+	if len(testVal.C) != 3 || testVal.C[0] != 1 || testVal.C[1] != 2 || testVal.C[2] != 3 || c[0] != 1 {
+		t.Errorf("testVal.C expected [1 2 3] but got %v", testVal.C)
+	}
+
+	if testVal.P != p || testVal.P.K != 1 {
+		t.Errorf("testVal.P expected {1} but got %v", *testVal.P)
+	}
+
+	if testVal.N != 2 {
+		t.Errorf("testVal.N expected 2 but got %d", testVal.N)
+	}
+}
@@ -0,0 +1,266 @@
+package gocsv
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Bindings reports how the fields of a struct are matched against the header of a Decoder.
+type Bindings struct {
+	// Fields lists every field bound to a column, in declaration order.
+	Fields []FieldBinding
+
+	// Missing lists every field whose column is not in the header.
+	Missing []MissingColumn
+
+	// Unclaimed lists the header columns not bound to any field, in header order.
+	Unclaimed []string
+}
+
+// FieldBinding describes the column a struct field is decoded from.
+type FieldBinding struct {
+	// Field is the name of the struct field.
+	Field string
+
+	// Column is the name of the column as it appears in the header.
+	Column string
+
+	// Index is the position of the column in the header.
+	Index int
+}
+
+// MissingColumn describes a struct field whose column is not in the header.
+type MissingColumn struct {
+	// Field is the name of the struct field.
+	Field string
+
+	// Column is the primary column name from the csv tag.
+	Column string
+
+	// Candidates are the unclaimed header columns closest to Column by edit distance, closest
+	// first.
+	Candidates []string
+
+	// Required is true if the field has the required option, so that the column must be present
+	// even with WithAllowMissingColumns.
+	Required bool
+}
+
+// maxCandidates is the most candidates suggested for a missing column.
+const maxCandidates = 3
+
+// boundField is a field along with the index of its column in the header, or -1 if the
+// column is missing, and the name of the column.
+type boundField struct {
+	field
+	column     int
+	columnName string
+}
+
+// Bindings reports how the fields of v, a struct or a pointer to one, are matched against the
+// Decoder's header: the column each field is bound to, the fields whose columns are missing
+// along with the closest header columns, and the header columns no field claims.
+func (dec *Decoder) Bindings(v interface{}) (*Bindings, error) {
+	if dec.hdr == nil && !dec.positionalOnly(v) {
+		return nil, ErrMissingHeader
+	}
+
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ErrInvalidType
+	}
+
+	b, _, err := dec.bind(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return dec.withCandidates(b), nil
+}
+
+// binding is the result of bind for a struct type, cached by the Decoder.
+type binding struct {
+	b     *Bindings
+	bound []boundField
+}
+
+// resetBindings forgets the bindings cached by bind, once an option changes how fields are
+// matched against the header.
+func (dec *Decoder) resetBindings() {
+	dec.bindings = &sync.Map{}
+}
+
+// bind matches the fields of the struct type t against the header. The result is cached for
+// each type and must not be modified; the candidates of missing columns are left for
+// withCandidates to fill in.
+func (dec *Decoder) bind(t reflect.Type) (*Bindings, []boundField, error) {
+	if c, ok := dec.bindings.Load(t); ok {
+		c := c.(binding)
+		return c.b, c.bound, nil
+	}
+
+	b := &Bindings{}
+	var bound []boundField
+
+	claimed := make([]bool, len(dec.header))
+
+	fields, err := dec.fields.fieldsOf(t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, f := range fields {
+		index, ok := dec.fields.position(i, f)
+		if ok {
+			ok = dec.header == nil || index < len(dec.header)
+		} else {
+			index, ok, err = dec.columnIndex(f.names)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if !ok {
+			b.Missing = append(b.Missing, MissingColumn{
+				Field:    f.name,
+				Column:   f.names[0],
+				Required: hasOption(f.tagOptions, "required"),
+			})
+			bound = append(bound, boundField{field: f, column: -1, columnName: f.names[0]})
+			continue
+		}
+
+		column := "#" + strconv.Itoa(index)
+		if index < len(dec.header) {
+			column = dec.header[index]
+			claimed[index] = true
+		}
+
+		bound = append(bound, boundField{field: f, column: index, columnName: column})
+		b.Fields = append(b.Fields, FieldBinding{
+			Field:  f.name,
+			Column: column,
+			Index:  index,
+		})
+	}
+
+	for i, name := range dec.header {
+		if !claimed[i] {
+			b.Unclaimed = append(b.Unclaimed, name)
+		}
+	}
+
+	dec.bindings.Store(t, binding{b: b, bound: bound})
+
+	return b, bound, nil
+}
+
+// withCandidates returns a copy of b with the candidates of each missing column filled in.
+func (dec *Decoder) withCandidates(b *Bindings) *Bindings {
+	c := &Bindings{
+		Fields:    append([]FieldBinding(nil), b.Fields...),
+		Missing:   append([]MissingColumn(nil), b.Missing...),
+		Unclaimed: append([]string(nil), b.Unclaimed...),
+	}
+
+	for i := range c.Missing {
+		c.Missing[i].Candidates = dec.candidates(c.Missing[i].Column, c.Unclaimed)
+	}
+
+	return c
+}
+
+// missingFails reports whether a column missing from the header fails the decode: one with the
+// required option, or one without a default unless missing columns are allowed.
+func (dec *Decoder) missingFails(bound []boundField) bool {
+	for _, f := range bound {
+		if f.column >= 0 {
+			continue
+		}
+
+		if hasOption(f.tagOptions, "required") {
+			return true
+		}
+
+		if _, ok := f.tag.Lookup("default"); !ok && !dec.allowMissingColumns {
+			return true
+		}
+	}
+
+	return false
+}
+
+// candidates returns the names closest to column by edit distance, after header normalization,
+// ignoring any that differ in more than about a third of their characters, or in at least as
+// many characters as either name has, so that short names are not suggested for each other.
+func (dec *Decoder) candidates(column string, names []string) []string {
+	type candidate struct {
+		name     string
+		distance int
+	}
+
+	target := []rune(dec.normalizeHeader(column))
+
+	var found []candidate
+	for _, name := range names {
+		n := []rune(dec.normalizeHeader(name))
+
+		limit := len(target)
+		if len(n) > limit {
+			limit = len(n)
+		}
+		limit = limit/3 + 1
+
+		if d := editDistance(target, n); d <= limit && d < min(len(target), len(n)) {
+			found = append(found, candidate{name: name, distance: d})
+		}
+	}
+
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].distance < found[j].distance
+	})
+
+	if len(found) > maxCandidates {
+		found = found[:maxCandidates]
+	}
+
+	var result []string
+	for _, c := range found {
+		result = append(result, c.name)
+	}
+
+	return result
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
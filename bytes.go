@@ -0,0 +1,54 @@
+package gocsv
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"reflect"
+)
+
+func byteEncoding(tag reflect.StructTag) string {
+	encoding := tag.Get("encoding")
+	if encoding == "" {
+		encoding = "base64"
+	}
+
+	return encoding
+}
+
+func decodeBytes(tag reflect.StructTag, value string) ([]byte, error) {
+	switch byteEncoding(tag) {
+	case "base64":
+		return base64.StdEncoding.DecodeString(value)
+	case "base64url":
+		return base64.URLEncoding.DecodeString(value)
+	case "hex":
+		return hex.DecodeString(value)
+	case "raw":
+		return []byte(value), nil
+	default:
+		return nil, ErrInvalidEncoding
+	}
+}
+
+func encodeBytes(tag reflect.StructTag, value []byte) (string, error) {
+	switch byteEncoding(tag) {
+	case "base64":
+		return base64.StdEncoding.EncodeToString(value), nil
+	case "base64url":
+		return base64.URLEncoding.EncodeToString(value), nil
+	case "hex":
+		return hex.EncodeToString(value), nil
+	case "raw":
+		return string(value), nil
+	default:
+		return "", ErrInvalidEncoding
+	}
+}
+
+func isByteSlice(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+func isByteArray(t reflect.Type) bool {
+	return t.Kind() == reflect.Array && t.Elem().Kind() == reflect.Uint8
+}
@@ -0,0 +1,110 @@
+package gocsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+)
+
+// WithCollectErrors makes Decode keep decoding the fields of a struct after one fails, and return
+// a *RowErrors holding a *FieldError for every cell that could not be decoded.
+func (dec *Decoder) WithCollectErrors() *Decoder {
+	dec.collectErrors = true
+	return dec
+}
+
+// WithMaxErrors lets Decode skip up to n records that fail to decode, moving on to the next
+// record each time. Records the Reader cannot parse, reported with a *csv.ParseError, count
+// against the same budget. The skipped records are listed by Report. Once more than n records fail,
+// Decode returns ErrTooManyErrors along with the error for the last one. Combine it with
+// WithAtomicDecode so that a skipped record leaves no partial data behind.
+func (dec *Decoder) WithMaxErrors(n int) *Decoder {
+	dec.maxErrors = n
+	return dec
+}
+
+// Report returns the records that failed to decode or to parse while the Decoder has an error
+// budget, in the order they were read.
+func (dec *Decoder) Report() []*RowErrors {
+	return append([]*RowErrors(nil), dec.report...)
+}
+
+// keepsRejected reports whether records that fail to decode are reported or written to a sink,
+// so that they must be kept as they were read.
+func (dec *Decoder) keepsRejected() bool {
+	return dec.maxErrors > 0 || dec.rejects != nil
+}
+
+// rejectRecord handles err, returned for the record at row, starting on line of the input. It
+// writes the record to the reject sink and adds it to the report, and returns nil if the
+// error budget lets decoding carry on past it.
+func (dec *Decoder) rejectRecord(row, line int, record []string, err error) error {
+	if !dec.keepsRejected() {
+		return err
+	}
+
+	rowErrs := rowErrors(row, line, record, err)
+
+	if dec.rejects != nil {
+		sinkErr := dec.reject(rowErrs)
+		if sinkErr != nil {
+			return errors.Join(err, sinkErr)
+		}
+	}
+
+	if dec.maxErrors <= 0 {
+		return err
+	}
+
+	dec.report = append(dec.report, rowErrs)
+	if len(dec.report) > dec.maxErrors {
+		return fmt.Errorf("%w: %w", ErrTooManyErrors, err)
+	}
+
+	return nil
+}
+
+// rowErrors returns err as a *RowErrors for the record at row, starting on line of the input.
+func rowErrors(row, line int, record []string, err error) *RowErrors {
+	var rowErrs *RowErrors
+	if errors.As(err, &rowErrs) {
+		rowErrs.Record = record
+		rowErrs.Line = line
+		return rowErrs
+	}
+
+	var rowErr *RowError
+	if errors.As(err, &rowErr) {
+		err = rowErr.Err
+	}
+
+	return &RowErrors{Row: row, Record: record, Line: line, Errors: []error{err}}
+}
+
+// parseError reports whether err, returned by the Reader, is a *csv.ParseError for a malformed
+// record that can be rejected like a record that fails to decode, and returns the line of the
+// input the record starts on.
+func parseError(err error) (int, bool) {
+	var perr *csv.ParseError
+	if errors.As(err, &perr) {
+		return perr.StartLine, true
+	}
+
+	return 0, false
+}
+
+// fieldPositioner is implemented by readers that report where the fields of the last record
+// start, like csv.Reader.
+type fieldPositioner interface {
+	FieldPos(field int) (line, column int)
+}
+
+// line returns the line of the input the last record read starts on.
+func (dec *Decoder) line() int {
+	if p, ok := dec.r.(fieldPositioner); ok {
+		line, _ := p.FieldPos(0)
+		return line
+	}
+
+	return dec.records
+}
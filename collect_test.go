@@ -0,0 +1,158 @@
+package gocsv_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rickbassham/gocsv"
+)
+
+func TestDecoder_WithCollectErrors(t *testing.T) {
+	data := strings.NewReader("x,1f,y,9")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d"}).WithCollectErrors()
+
+	testVal := &intTest{}
+
+	err := dec.Decode(testVal)
+
+	var rowErrs *gocsv.RowErrors
+	if !errors.As(err, &rowErrs) {
+		t.Errorf("expected *gocsv.RowErrors but got %v", err)
+		return
+	}
+
+	if rowErrs.Row != 1 {
+		t.Errorf("rowErrs.Row expected 1 but got %d", rowErrs.Row)
+	}
+
+	if len(rowErrs.Errors) != 3 {
+		t.Errorf("expected 3 errors but got %d: %v", len(rowErrs.Errors), rowErrs.Errors)
+		return
+	}
+
+	var fieldErr *gocsv.FieldError
+	if !errors.As(rowErrs.Errors[1], &fieldErr) {
+		t.Errorf("expected *gocsv.FieldError but got %v", rowErrs.Errors[1])
+		return
+	}
+
+	if fieldErr.Column != "c" || fieldErr.Field != "C" || fieldErr.Value != "y" {
+		t.Errorf("expected column c, field C, value y but got %s, %s, %s", fieldErr.Column, fieldErr.Field, fieldErr.Value)
+	}
+
+	if testVal.B != 0x1f {
+		t.Errorf("testVal.B expected 31 but got %d", testVal.B)
+	}
+
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected strconv.ErrSyntax but got %v", err)
+	}
+
+	if !strings.HasPrefix(err.Error(), `gocsv: row 1: column "a": `) {
+		t.Errorf("expected error to start with the row and column but got %s", err.Error())
+	}
+}
+
+func TestDecoder_WithMaxErrors(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,x\nc,3\nd,y")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithMaxErrors(2).WithAtomicDecode()
+
+	var vals []simpleTest
+
+	for {
+		testVal := simpleTest{}
+
+		err := dec.Decode(&testVal)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Error(err.Error())
+			return
+		}
+
+		vals = append(vals, testVal)
+	}
+
+	if len(vals) != 2 || vals[0].StringVal != "a" || vals[1].StringVal != "c" {
+		t.Errorf("expected records a and c but got %v", vals)
+	}
+
+	report := dec.Report()
+	if len(report) != 2 {
+		t.Errorf("expected 2 rejected records but got %d", len(report))
+		return
+	}
+
+	if report[0].Row != 2 || strings.Join(report[0].Record, ",") != "b,x" {
+		t.Errorf("expected row 2 b,x but got %d %v", report[0].Row, report[0].Record)
+	}
+
+	if report[1].Row != 4 || strings.Join(report[1].Record, ",") != "d,y" {
+		t.Errorf("expected row 4 d,y but got %d %v", report[1].Row, report[1].Record)
+	}
+}
+
+func TestDecoder_WithMaxErrorsExceeded(t *testing.T) {
+	data := strings.NewReader("str,n\na,x\nb,y\nc,3")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithMaxErrors(1).WithCollectErrors()
+
+	testVal := &simpleTest{}
+
+	err := dec.Decode(testVal)
+	if !errors.Is(err, gocsv.ErrTooManyErrors) {
+		t.Errorf("expected ErrTooManyErrors but got %v", err)
+		return
+	}
+
+	var rowErrs *gocsv.RowErrors
+	if !errors.As(err, &rowErrs) || rowErrs.Row != 2 {
+		t.Errorf("expected *gocsv.RowErrors for row 2 but got %v", err)
+	}
+
+	if len(dec.Report()) != 2 {
+		t.Errorf("expected 2 rejected records but got %d", len(dec.Report()))
+	}
+}
+
+func TestDecoder_WithMaxErrorsParseError(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,2,3\nc\"d,4\ne,5")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithMaxErrors(1)
+
+	testVal := &simpleTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	err = dec.Decode(testVal)
+
+	var perr *csv.ParseError
+	if !errors.Is(err, gocsv.ErrTooManyErrors) || !errors.As(err, &perr) || perr.Err != csv.ErrBareQuote {
+		t.Errorf("expected ErrTooManyErrors with csv.ErrBareQuote but got %v", err)
+	}
+
+	report := dec.Report()
+	if len(report) != 2 {
+		t.Errorf("expected 2 rejected records but got %d", len(report))
+		return
+	}
+
+	if report[0].Row != 2 || report[0].Line != 3 || strings.Join(report[0].Record, ",") != "b,2,3" || !errors.Is(report[0], csv.ErrFieldCount) {
+		t.Errorf("expected row 2 b,2,3 with csv.ErrFieldCount but got %d %v %v", report[0].Row, report[0].Record, report[0])
+	}
+
+	if report[1].Row != 3 || report[1].Line != 4 {
+		t.Errorf("expected row 3 on line 4 but got row %d line %d", report[1].Row, report[1].Line)
+	}
+}
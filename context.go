@@ -0,0 +1,63 @@
+package gocsv
+
+import (
+	"reflect"
+)
+
+// FieldContext describes the column a value is decoded from or encoded to.
+type FieldContext struct {
+	// Column is the name of the column as it appears in the header, or "#n" for the n-th column
+	// when there is no header.
+	Column string
+
+	// Field is the name of the struct field, empty for map values.
+	Field string
+
+	// Tag is the struct tag of the field, including the tags composed by a Mapping or Schema.
+	Tag reflect.StructTag
+
+	// Row is the number of the record being decoded or encoded, starting at 1 and not counting
+	// the header.
+	Row int
+
+	// NilValues are the Decoder's nil values, or the Encoder's nil value.
+	NilValues []string
+
+	// Normalization is the Decoder's normalization, without the options of the field.
+	Normalization Normalization
+}
+
+// FieldUnmarshaler is any type that can unmarshal it's own csv value, given the column it is
+// read from. It takes precedence over ValueUnmarshaler.
+type FieldUnmarshaler interface {
+	UnmarshalCSVField(ctx FieldContext, raw string) error
+}
+
+// FieldMarshaler is any type that can marshal it's own csv value, given the column it is written
+// to. It takes precedence over ValueMarshaller.
+type FieldMarshaler interface {
+	MarshalCSVField(ctx FieldContext) (string, error)
+}
+
+// fieldContext returns the FieldContext for the column and the field name and tag decoded from it.
+func (dec *Decoder) fieldContext(column, name string, tag reflect.StructTag) FieldContext {
+	return FieldContext{
+		Column:        column,
+		Field:         name,
+		Tag:           tag,
+		Row:           dec.row,
+		NilValues:     dec.nilVals,
+		Normalization: dec.normalization,
+	}
+}
+
+// fieldContext returns the FieldContext for the column and the field name and tag encoded to it.
+func (enc *Encoder) fieldContext(column, name string, tag reflect.StructTag) FieldContext {
+	return FieldContext{
+		Column:    column,
+		Field:     name,
+		Tag:       tag,
+		Row:       enc.row,
+		NilValues: []string{enc.nilVal},
+	}
+}
@@ -1,8 +1,10 @@
 package gocsv
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 )
 
@@ -14,9 +16,30 @@ type Reader interface {
 // Decoder will allow you to decode lines of CSV to structs.
 type Decoder struct {
 	r                   Reader
+	header              []string
 	hdr                 map[string]int
-	nilVal              string
+	duplicates          map[string]bool
+	headerNormalizers   []func(string) string
+	nilVals             []string
 	allowMissingColumns bool
+	allowAmbiguous      bool
+	fields              fieldOptions
+	inference           Inference
+	normalization       Normalization
+	row                 int
+	beforeHooks         []func(v interface{}, raw []string) error
+	afterHooks          []func(v interface{}) error
+	atomic              bool
+	zero                bool
+	collectErrors       bool
+	maxErrors           int
+	report              []*RowErrors
+	rejects             Writer
+	rejectHeader        bool
+	records             int
+	capacityHint        int
+	preserveOrder       bool
+	bindings            *sync.Map
 }
 
 // ValueUnmarshaler is any type that can unmarshal it's own csv value.
@@ -29,6 +52,12 @@ type Unmarshaler interface {
 	UnmarshalCSV([]string) error
 }
 
+// HeaderUnmarshaler is an interface you can implement in your struct for fine control over the
+// decoding of the CSV. Along with the line, you will get the header as it appears in the csv.
+type HeaderUnmarshaler interface {
+	UnmarshalCSVRecord(hdr []string, line []string) error
+}
+
 // MapUnmarshaler is an interface you can implement in your struct for fine control over the decoding of the CSV.
 // Instead of receiving a []string, you will get a map[string]string.
 type MapUnmarshaler interface {
@@ -47,8 +76,11 @@ func Must(dec *Decoder, err error) *Decoder {
 // NewDecoder returns a new Decoder.
 func NewDecoder(r Reader) *Decoder {
 	return &Decoder{
-		hdr: nil,
-		r:   r,
+		hdr:       nil,
+		r:         r,
+		nilVals:   []string{""},
+		inference: DefaultInference,
+		bindings:  &sync.Map{},
 	}
 }
 
@@ -59,57 +91,225 @@ func (dec *Decoder) ReadHeader() (*Decoder, error) {
 		return nil, err
 	}
 
+	dec.records++
+
 	return dec.WithHeader(line), nil
 }
 
 // WithHeader allows you to specify a header to use. Useful if your CSV doesn't have a header line,
 // or if you just don't like the header that is there.
 func (dec *Decoder) WithHeader(h []string) *Decoder {
-	hdr := map[string]int{}
-	for i := 0; i < len(h); i++ {
-		hdr[h[i]] = i
-	}
+	dec.header = append([]string(nil), h...)
+	dec.buildHeader()
 
-	dec.hdr = hdr
+	return dec
+}
+
+// WithHeaderNormalizer sets functions that are applied, in order, to both the header cells and
+// the csv tag names before they are matched, such as HeaderTrimSpace and HeaderLower. Maps
+// decoded by the Decoder are keyed by the normalized names. When several header cells
+// normalize to the same name, binding a field to it returns ErrAmbiguousColumn, and maps take
+// the first of the columns.
+func (dec *Decoder) WithHeaderNormalizer(normalizers ...func(string) string) *Decoder {
+	dec.headerNormalizers = normalizers
+	dec.buildHeader()
 
 	return dec
 }
 
-// Header will return the fields used as the header for the decoder.
-func (dec *Decoder) Header() []string {
-	hdr := make([]string, len(dec.hdr))
+func (dec *Decoder) buildHeader() {
+	dec.resetBindings()
+
+	if dec.header == nil {
+		return
+	}
+
+	hdr := map[string]int{}
+	duplicates := map[string]bool{}
+	for i := 0; i < len(dec.header); i++ {
+		name := dec.normalizeHeader(dec.header[i])
+		if _, ok := hdr[name]; ok {
+			duplicates[name] = true
+			continue
+		}
 
-	for key, i := range dec.hdr {
-		hdr[i] = key
+		hdr[name] = i
 	}
 
-	return hdr
+	dec.hdr = hdr
+	dec.duplicates = duplicates
+}
+
+// Header will return the fields used as the header for the decoder, as they appeared before
+// any header normalization.
+func (dec *Decoder) Header() []string {
+	return append([]string(nil), dec.header...)
 }
 
 // WithNilValue will set the empty value for the Decoder.
 func (dec *Decoder) WithNilValue(val string) *Decoder {
-	dec.nilVal = val
+	return dec.WithNilValues(val)
+}
+
+// WithNilValues sets every value the Decoder should treat as nil, such as "", "NULL", and "\N".
+// A field can override these with a comma-separated nil struct tag, like `nil:"NULL,N/A"`.
+func (dec *Decoder) WithNilValues(vals ...string) *Decoder {
+	dec.nilVals = vals
 	return dec
 }
 
 // WithAllowMissingColumns prevents the Decoder from returning an error if a column
-// defined in the struct is missing from the csv.
+// defined in the struct is missing from the csv, unless the field has the required option,
+// like `csv:"id,required"`. Fields with a default never need their column, even without it.
 func (dec *Decoder) WithAllowMissingColumns() *Decoder {
 	dec.allowMissingColumns = true
 	return dec
 }
 
+// WithPositionalFields binds the fields of a struct to columns in declaration order, the first
+// field to the first column and so on, instead of by name. Fields can also be bound to a
+// zero-based column index individually with a tag like `csv:"#3"`. When every field is bound by
+// position, Decode does not need a header.
+func (dec *Decoder) WithPositionalFields() *Decoder {
+	dec.fields.positional = true
+	dec.resetBindings()
+	return dec
+}
+
+// WithAllowAmbiguousAliases makes the Decoder bind a field to the first of its aliases present
+// in the header, and to the first of several header cells with the same normalized name,
+// instead of returning ErrAmbiguousColumn.
+func (dec *Decoder) WithAllowAmbiguousAliases() *Decoder {
+	dec.allowAmbiguous = true
+	dec.resetBindings()
+	return dec
+}
+
+// WithTagName makes the Decoder read column names from a struct tag other than csv, such as db
+// or json. Only the name is read from that tag: csv options like omitempty are still read from
+// the csv tag, and options like format and nil from their own struct tags.
+func (dec *Decoder) WithTagName(name string) *Decoder {
+	dec.fields.tagName = name
+	dec.resetBindings()
+	return dec
+}
+
+// WithUntaggedFields maps exported struct fields without a column name in their tag to the
+// column named by naming, such as NameSnakeCase. Fields tagged "-" are still skipped.
+func (dec *Decoder) WithUntaggedFields(naming func(string) string) *Decoder {
+	dec.fields.naming = naming
+	dec.resetBindings()
+	return dec
+}
+
+// WithMapping registers a Mapping, built with Map, that replaces the struct tags of its type.
+func (dec *Decoder) WithMapping(m Mapper) *Decoder {
+	dec.fields.addMapping(m.csvMapping())
+	dec.resetBindings()
+	return dec
+}
+
+// WithInference sets the rules used to infer Go values when decoding into interface{}
+// destinations.
+func (dec *Decoder) WithInference(inf Inference) *Decoder {
+	dec.inference = inf
+	return dec
+}
+
+// WithNormalization sets the normalizations applied to every cell before it is compared
+// against the nil values and converted. Fields can enable more with csv tag options.
+func (dec *Decoder) WithNormalization(n Normalization) *Decoder {
+	dec.normalization = n
+	return dec
+}
+
 // Decode will read a line from the Reader and populate the fields in the struct passed in.
+// A csv tag can list aliases for its column, like `csv:"zip|postal_code|zip code"`, and the
+// field is bound to the first alias present in the header. A default struct tag, like
+// `default:"0"`, gives the value used when the cell holds a nil value or the column is missing.
+// Fields tagged with the json option, such as `csv:"meta,json"`, are unmarshaled from the
+// cell using encoding/json, with the nil value treated as a JSON null. Pointer fields are set
+// to nil when the cell holds a nil value.
+//
+// Decoding into a map[string]T converts every column in the header to T, which may be any
+// type supported as a struct field. A nil map behind a pointer is allocated first.
+//
+// Decoding into a map[string]interface{}, a *[]interface{}, or a struct field of type
+// interface{} stores values converted according to the Decoder's Inference rules.
+//
+// Hooks run before and after the record is decoded: the BeforeDecodeCSV and AfterDecodeCSV
+// methods of v, then the functions given to WithBeforeDecode and WithAfterDecode. Their errors
+// are returned as a *RowError.
+//
+// With WithCollectErrors, every field of a struct is decoded even after one fails, and the
+// errors are returned together as a *RowErrors. With WithMaxErrors, records that fail are
+// skipped and listed by Report until the budget runs out. With WithRejectSink, records that fail
+// are also written to the sink.
 func (dec *Decoder) Decode(v interface{}) error {
-	if dec.hdr == nil {
+	if dec.hdr == nil && !dec.positionalOnly(v) {
 		return ErrMissingHeader
 	}
 
-	line, err := dec.r.Read()
+	for {
+		line, err := dec.r.Read()
+		start, malformed := parseError(err)
+		if err != nil && !malformed {
+			return err
+		}
+
+		dec.row++
+		dec.records++
+
+		raw := line
+		if dec.keepsRejected() {
+			raw = append([]string(nil), line...)
+		}
+
+		if !malformed {
+			err = dec.decodeRecord(v, line)
+			if err == nil {
+				return nil
+			}
+
+			start = dec.line()
+		}
+
+		err = dec.rejectRecord(dec.row, start, raw, err)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// decodeRecord runs the hooks for v and stores the record line in it.
+func (dec *Decoder) decodeRecord(v interface{}, line []string) error {
+	target, commit := dec.target(v)
+
+	err := dec.beforeDecode(target, line)
+	if err != nil {
+		return err
+	}
+
+	err = dec.decodeLine(target, line)
+	if err != nil {
+		return err
+	}
+
+	err = dec.afterDecode(target)
 	if err != nil {
 		return err
 	}
 
+	commit()
+	return nil
+}
+
+// decodeLine stores the record line in v.
+func (dec *Decoder) decodeLine(v interface{}, line []string) error {
+	if u, ok := v.(HeaderUnmarshaler); ok {
+		return u.UnmarshalCSVRecord(dec.Header(), line)
+	}
+
 	if u, ok := v.(Unmarshaler); ok {
 		return u.UnmarshalCSV(line)
 	}
@@ -119,14 +319,35 @@ func (dec *Decoder) Decode(v interface{}) error {
 	}
 
 	if u, ok := v.(map[string]string); ok {
+		if u == nil {
+			return ErrInvalidType
+		}
+
 		return dec.decodeMap(line, u)
 	}
 
 	if u, ok := v.(*map[string]string); ok {
+		if *u == nil {
+			*u = map[string]string{}
+		}
+
 		return dec.decodeMap(line, *u)
 	}
 
+	if u, ok := v.(*[]interface{}); ok {
+		*u = dec.decodeDynamicSlice(line)
+		return nil
+	}
+
 	t := reflect.TypeOf(v)
+	if isStringMap(t) {
+		if reflect.ValueOf(v).IsNil() {
+			return ErrInvalidType
+		}
+
+		return dec.decodeTypedMap(line, reflect.ValueOf(v))
+	}
+
 	if t.Kind() != reflect.Ptr {
 		return ErrInvalidType
 	}
@@ -134,168 +355,108 @@ func (dec *Decoder) Decode(v interface{}) error {
 	t = t.Elem()
 	val := reflect.Indirect(reflect.ValueOf(v))
 
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		tag, tagOptions := parseTag(f.Tag.Get("csv"))
-		if tag == "" || tag == "-" {
-			continue
+	if isStringMap(t) {
+		if val.IsNil() {
+			val.Set(reflect.MakeMap(t))
 		}
 
-		index, ok := dec.hdr[tag]
-		if !ok {
-			if dec.allowMissingColumns {
+		return dec.decodeTypedMap(line, val)
+	}
+
+	if t.Kind() != reflect.Struct {
+		return ErrInvalidType
+	}
+
+	b, fields, err := dec.bind(t)
+	if err != nil {
+		return err
+	}
+
+	if dec.missingFails(fields) {
+		return &MissingColumnsError{Bindings: dec.withCandidates(b)}
+	}
+
+	var errs []error
+
+	for _, f := range fields {
+		var value string
+
+		if f.column < 0 || f.column >= len(line) {
+			def, ok := f.tag.Lookup("default")
+			if ok {
+				value = def
+			} else if f.column < 0 || dec.allowMissingColumns {
+				continue
+			} else if dec.collectErrors {
+				errs = append(errs, &FieldError{Row: dec.row, Column: f.columnName, Field: f.name, Err: ErrMissingColumn})
 				continue
 			} else {
 				return ErrMissingColumn
 			}
+		} else {
+			value = line[f.column]
 		}
 
-		if line[index] == dec.nilVal && omitEmpty(tagOptions) {
-			continue
-		}
+		valf, _ := fieldByIndex(val, f.index, true)
 
-		valf := val.FieldByName(f.Name)
-		kind := f.Type.Kind()
+		err = dec.decodeField(valf, dec.fieldContext(f.columnName, f.name, f.tag), f.tagOptions, value)
+		if err != nil {
+			if !dec.collectErrors {
+				return err
+			}
 
-		if kind == reflect.Ptr {
-			kind = f.Type.Elem().Kind()
-			valf.Set(reflect.New(f.Type.Elem()))
-			valf = reflect.Indirect(valf)
+			errs = append(errs, &FieldError{Row: dec.row, Column: f.columnName, Field: f.name, Value: value, Err: err})
 		}
+	}
 
-		i := valf.Interface()
+	if len(errs) > 0 {
+		return &RowErrors{Row: dec.row, Record: line, Errors: errs}
+	}
 
-		if u, ok := i.(ValueUnmarshaler); ok {
-			if kind != reflect.Ptr {
-				return ErrNonPointerReceiver
-			}
+	return nil
+}
 
-			err := u.UnmarshalCSVValue(line[index])
-			if err != nil {
-				return err
-			}
-			continue
-		}
+// columnIndex returns the index of the first of names present in the header.
+func (dec *Decoder) columnIndex(names []string) (int, bool, error) {
+	index, found := 0, false
 
-		if u, ok := valf.Addr().Interface().(ValueUnmarshaler); ok {
-			err := u.UnmarshalCSVValue(line[index])
-			if err != nil {
-				return err
-			}
+	for _, name := range names {
+		name = dec.normalizeHeader(name)
+
+		i, ok := dec.hdr[name]
+		if !ok {
 			continue
 		}
 
-		switch kind {
-		case reflect.String:
-			valf.SetString(line[index])
-		case reflect.Bool:
-			boolVal, err := strconv.ParseBool(line[index])
-			if err != nil {
-				return err
-			}
-			valf.SetBool(boolVal)
-		case reflect.Int:
-			err := decodeInt(valf, f.Tag, 0, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Int8:
-			err := decodeInt(valf, f.Tag, 8, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Int16:
-			err := decodeInt(valf, f.Tag, 16, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Int32:
-			err := decodeInt(valf, f.Tag, 32, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Int64:
-			err := decodeInt(valf, f.Tag, 64, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Uint:
-			err := decodeUint(valf, f.Tag, 0, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Uint8:
-			err := decodeUint(valf, f.Tag, 8, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Uint16:
-			err := decodeUint(valf, f.Tag, 16, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Uint32:
-			err := decodeUint(valf, f.Tag, 32, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Uint64:
-			err := decodeUint(valf, f.Tag, 64, line[index])
-			if err != nil {
-				return err
-			}
-		case reflect.Float32:
-			floatVal, err := strconv.ParseFloat(line[index], 32)
-			if err != nil {
-				return err
-			}
-			valf.SetFloat(floatVal)
-		case reflect.Float64:
-			floatVal, err := strconv.ParseFloat(line[index], 64)
-			if err != nil {
-				return err
-			}
-			valf.SetFloat(floatVal)
-		case reflect.Struct:
-			if valf.Type() == reflect.TypeOf(time.Time{}) {
-				format := f.Tag.Get("format")
-				if format == "" {
-					format = time.RFC3339
-				}
-
-				var timeVal time.Time
-				var err error
+		if dec.duplicates[name] && !dec.allowAmbiguous {
+			return 0, false, fmt.Errorf("%w: %q appears more than once in the header", ErrAmbiguousColumn, name)
+		}
 
-				tz := f.Tag.Get("tz")
-				if tz == "" {
-					timeVal, err = time.Parse(format, line[index])
-				} else {
-					var loc *time.Location
-					loc, err = time.LoadLocation(tz)
-					if err != nil {
-						return err
-					}
+		if !found {
+			index, found = i, true
 
-					timeVal, err = time.ParseInLocation(format, line[index], loc)
-				}
-				if err != nil {
-					return err
-				}
-				valf.Set(reflect.ValueOf(timeVal))
-			} else {
-				return ErrInvalidDestType
+			if dec.allowAmbiguous {
+				break
 			}
-		default:
-			return ErrInvalidDestType
+		} else if i != index {
+			return 0, false, ErrAmbiguousColumn
 		}
 	}
 
-	return nil
+	return index, found, nil
 }
 
 func (dec *Decoder) decodeMapUnmarshaler(line []string, u MapUnmarshaler) error {
 	m := map[string]string{}
 	for k, v := range dec.hdr {
+		if v >= len(line) {
+			if dec.allowMissingColumns {
+				continue
+			}
+
+			return ErrMissingColumn
+		}
+
 		m[k] = line[v]
 	}
 
@@ -304,11 +465,268 @@ func (dec *Decoder) decodeMapUnmarshaler(line []string, u MapUnmarshaler) error
 
 func (dec *Decoder) decodeMap(line []string, u map[string]string) error {
 	for k, v := range dec.hdr {
-		u[k] = line[v]
+		if v >= len(line) {
+			if dec.allowMissingColumns {
+				continue
+			}
+
+			return ErrMissingColumn
+		}
+
+		u[k] = dec.normalization.apply(line[v])
 	}
 	return nil
 }
 
+func (dec *Decoder) decodeTypedMap(line []string, m reflect.Value) error {
+	keyType := m.Type().Key()
+	elemType := m.Type().Elem()
+
+	for k, v := range dec.hdr {
+		if v >= len(line) {
+			if dec.allowMissingColumns {
+				continue
+			}
+
+			return ErrMissingColumn
+		}
+
+		elem := reflect.New(elemType).Elem()
+
+		err := dec.decodeField(elem, dec.fieldContext(k, "", ""), nil, line[v])
+		if err != nil {
+			return err
+		}
+
+		m.SetMapIndex(reflect.ValueOf(k).Convert(keyType), elem)
+	}
+	return nil
+}
+
+// isNil reports whether value is one of the nil values for a field, taken from its nil struct
+// tag if present and from the Decoder otherwise.
+func (dec *Decoder) isNil(value string, tag reflect.StructTag) bool {
+	nilVals, ok := nilValues(tag)
+	if !ok {
+		nilVals = dec.nilVals
+	}
+
+	for _, nilVal := range nilVals {
+		if value == nilVal {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeField normalizes value and stores it in valf, handling the omitempty and json options,
+// interface{} destinations, pointers, FieldUnmarshaler, and ValueUnmarshaler before falling back
+// to decodeValue. Pointers, including pointers to pointers, are allocated as needed and are set to
+// nil when value is a nil value.
+func (dec *Decoder) decodeField(valf reflect.Value, ctx FieldContext, tagOptions []string, value string) error {
+	tag := ctx.Tag
+	normalization := dec.normalization | tagNormalization(tagOptions)
+	value = normalization.apply(value)
+
+	isNil := dec.isNil(value, tag)
+	if isNil {
+		if def, ok := tag.Lookup("default"); ok {
+			value, isNil = def, false
+		}
+	}
+
+	if isNil && omitEmpty(tagOptions) {
+		return nil
+	}
+
+	if hasOption(tagOptions, "json") {
+		return decodeJSON(valf, value, isNil)
+	}
+
+	if isEmptyInterface(valf.Type()) {
+		dec.decodeDynamic(valf, value, isNil)
+		return nil
+	}
+
+	if valf.Kind() == reflect.Ptr && isNil {
+		valf.Set(reflect.Zero(valf.Type()))
+		return nil
+	}
+
+	for valf.Kind() == reflect.Ptr {
+		valf.Set(reflect.New(valf.Type().Elem()))
+		valf = valf.Elem()
+	}
+
+	if n, ok := asNullable(valf); ok {
+		if isNil {
+			n.setCSVValid(false)
+			return nil
+		}
+
+		err := dec.decodeField(n.csvValue(), ctx, tagOptions, value)
+		if err != nil {
+			return err
+		}
+
+		n.setCSVValid(true)
+		return nil
+	}
+
+	m := methodsOf(valf.Type())
+	if m.unmarshalErr != nil {
+		return m.unmarshalErr
+	}
+
+	if (m.fieldUnmarshaler || m.valueUnmarshaler) && valf.Kind() == reflect.Map && valf.IsNil() {
+		valf.Set(reflect.MakeMap(valf.Type()))
+	}
+
+	if m.fieldUnmarshaler {
+		return valf.Addr().Interface().(FieldUnmarshaler).UnmarshalCSVField(ctx, value)
+	}
+
+	if m.valueUnmarshaler {
+		return valf.Addr().Interface().(ValueUnmarshaler).UnmarshalCSVValue(value)
+	}
+
+	return decodeValue(valf, tag, value)
+}
+
+// decodeValue converts value according to the kind of valf and any options in tag, and
+// stores the result in valf.
+func decodeValue(valf reflect.Value, tag reflect.StructTag, value string) error {
+	switch valf.Kind() {
+	case reflect.String:
+		valf.SetString(value)
+	case reflect.Bool:
+		boolVal, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		valf.SetBool(boolVal)
+	case reflect.Int:
+		err := decodeInt(valf, tag, 0, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Int8:
+		err := decodeInt(valf, tag, 8, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Int16:
+		err := decodeInt(valf, tag, 16, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Int32:
+		err := decodeInt(valf, tag, 32, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Int64:
+		err := decodeInt(valf, tag, 64, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Uint:
+		err := decodeUint(valf, tag, 0, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Uint8:
+		err := decodeUint(valf, tag, 8, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Uint16:
+		err := decodeUint(valf, tag, 16, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Uint32:
+		err := decodeUint(valf, tag, 32, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Uint64:
+		err := decodeUint(valf, tag, 64, value)
+		if err != nil {
+			return err
+		}
+	case reflect.Float32:
+		floatVal, err := strconv.ParseFloat(value, 32)
+		if err != nil {
+			return err
+		}
+		valf.SetFloat(floatVal)
+	case reflect.Float64:
+		floatVal, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		valf.SetFloat(floatVal)
+	case reflect.Slice:
+		if !isByteSlice(valf.Type()) {
+			return invalidDestType(valf.Type())
+		}
+
+		b, err := decodeBytes(tag, value)
+		if err != nil {
+			return err
+		}
+		valf.SetBytes(b)
+	case reflect.Array:
+		if !isByteArray(valf.Type()) {
+			return invalidDestType(valf.Type())
+		}
+
+		b, err := decodeBytes(tag, value)
+		if err != nil {
+			return err
+		}
+		if len(b) != valf.Len() {
+			return ErrInvalidByteLength
+		}
+		reflect.Copy(valf, reflect.ValueOf(b))
+	case reflect.Struct:
+		if isTime(valf.Type()) {
+			format := tag.Get("format")
+			if format == "" {
+				format = time.RFC3339
+			}
+
+			var timeVal time.Time
+			var err error
+
+			tz := tag.Get("tz")
+			if tz == "" {
+				timeVal, err = time.Parse(format, value)
+			} else {
+				var loc *time.Location
+				loc, err = time.LoadLocation(tz)
+				if err != nil {
+					return err
+				}
+
+				timeVal, err = time.ParseInLocation(format, value, loc)
+			}
+			if err != nil {
+				return err
+			}
+			valf.Set(reflect.ValueOf(timeVal).Convert(valf.Type()))
+		} else {
+			return invalidDestType(valf.Type())
+		}
+	default:
+		return invalidDestType(valf.Type())
+	}
+
+	return nil
+}
+
 func decodeInt(valf reflect.Value, tag reflect.StructTag, bitSize int, value string) error {
 	b, err := base(tag)
 	if err != nil {
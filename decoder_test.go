@@ -158,7 +158,7 @@ func TestDecoder_WithHeaderUnexpectedSubset(t *testing.T) {
 	testVal := simpleTest{}
 
 	err := dec.Decode(&testVal)
-	if err != gocsv.ErrMissingColumn {
+	if !errors.Is(err, gocsv.ErrMissingColumn) {
 		t.Error("expected gocsv.ErrMissingColumn")
 	}
 }
@@ -417,6 +417,170 @@ func TestDecoder_MapUnmarshaler(t *testing.T) {
 	}
 }
 
+func TestDecoder_HeaderUnmarshaler(t *testing.T) {
+	data := strings.NewReader("n,str\n1234,string val")
+	r := csv.NewReader(data)
+	dec, err := gocsv.NewDecoder(r).ReadHeader()
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	testVal := &headerMarshalerTest{}
+
+	err = dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.StringVal != "string val" {
+		t.Errorf("testVal.StringVal expected string val but got %s", testVal.StringVal)
+	}
+
+	if testVal.OtherVal != 1234 {
+		t.Errorf("testVal.OtherVal expected %s but got %d", "1234", testVal.OtherVal)
+	}
+}
+
+func TestDecoder_FieldUnmarshaler(t *testing.T) {
+	data := strings.NewReader("1,2\n3,4")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"mass", "height"})
+
+	testVal := &fieldMarshalerTest{}
+
+	for i := 0; i < 2; i++ {
+		err := dec.Decode(testVal)
+		if err != nil {
+			t.Error(err.Error())
+			return
+		}
+	}
+
+	if testVal.Weight != "mass:2:3 kg" {
+		t.Errorf("testVal.Weight expected mass:2:3 kg but got %s", testVal.Weight)
+	}
+
+	if testVal.Height == nil || *testVal.Height != "height:2:4 cm" {
+		t.Errorf("testVal.Height expected height:2:4 cm but got %v", testVal.Height)
+	}
+}
+
+func TestDecoder_ValueReceiverMap(t *testing.T) {
+	data := strings.NewReader("a;b")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"tags"})
+
+	testVal := &valueReceiverTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(testVal.Tags) != 2 || !testVal.Tags["a"] || !testVal.Tags["b"] {
+		t.Errorf("testVal.Tags expected map[a:true b:true] but got %v", testVal.Tags)
+	}
+}
+
+func TestDecoder_ValueReceiverNonReference(t *testing.T) {
+	data := strings.NewReader("a")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a"})
+
+	testVal := &valueReceiverStringTest{}
+
+	err := dec.Decode(testVal)
+	if !errors.Is(err, gocsv.ErrNonPointerReceiver) {
+		t.Errorf("expected ErrNonPointerReceiver but got %v", err)
+		return
+	}
+
+	if !strings.Contains(err.Error(), "gocsv_test.valueReceiverString") {
+		t.Errorf("expected error to name gocsv_test.valueReceiverString but got %s", err.Error())
+	}
+}
+
+func TestDecoder_PointerPointer(t *testing.T) {
+	data := strings.NewReader("12,val\n,")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"})
+
+	testVal := &pointerPointerTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A == nil || *testVal.A == nil || **testVal.A != 12 {
+		t.Errorf("testVal.A expected 12 but got %v", testVal.A)
+	}
+
+	if testVal.B == nil || *testVal.B == nil || **testVal.B != "prefix val" {
+		t.Errorf("testVal.B expected prefix val but got %v", testVal.B)
+	}
+
+	err = dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A != nil {
+		t.Errorf("testVal.A expected nil but got %v", testVal.A)
+	}
+
+	if testVal.B != nil {
+		t.Errorf("testVal.B expected nil but got %v", testVal.B)
+	}
+}
+
+func TestDecoder_NamedTime(t *testing.T) {
+	data := strings.NewReader("2020-01-02,2021-03-04")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"})
+
+	testVal := &namedTimeTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	expected := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+	if !time.Time(testVal.A).Equal(expected) {
+		t.Errorf("testVal.A expected %v but got %v", expected, time.Time(testVal.A))
+	}
+
+	expected = time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	if testVal.B == nil || !time.Time(*testVal.B).Equal(expected) {
+		t.Errorf("testVal.B expected %v but got %v", expected, testVal.B)
+	}
+}
+
+func TestDecoder_InvalidDestType(t *testing.T) {
+	data := strings.NewReader("a")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a"})
+
+	testVal := &invalidSliceTest{}
+
+	err := dec.Decode(testVal)
+	if !errors.Is(err, gocsv.ErrInvalidDestType) {
+		t.Errorf("expected ErrInvalidDestType but got %v", err)
+		return
+	}
+
+	if !strings.Contains(err.Error(), "[]string") {
+		t.Errorf("expected error to name []string but got %s", err.Error())
+	}
+}
+
 func TestDecoder_Bool(t *testing.T) {
 	data := strings.NewReader("true,false")
 	r := csv.NewReader(data)
@@ -538,3 +702,1001 @@ func TestDecoder_TimeWithTimezone(t *testing.T) {
 		t.Errorf("testVal.B expected %s but got %s", time.Date(2019, 03, 9, 6, 0, 0, 0, time.UTC).String(), testVal.B.String())
 	}
 }
+
+func TestDecoder_JSON(t *testing.T) {
+	data := strings.NewReader(`"{""k"":1}","{""x"":""y""}","[1,2,3]",`)
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d"})
+
+	testVal := &jsonTest{D: &jsonMeta{K: 5}}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A.K != 1 {
+		t.Errorf("testVal.A.K expected %d but got %d", 1, testVal.A.K)
+	}
+
+	if testVal.B["x"] != "y" {
+		t.Errorf("testVal.B[x] expected %s but got %s", "y", testVal.B["x"])
+	}
+
+	if len(testVal.C) != 3 || testVal.C[2] != 3 {
+		t.Errorf("testVal.C expected %v but got %v", []int{1, 2, 3}, testVal.C)
+	}
+
+	if testVal.D != nil {
+		t.Error("testVal.D should be nil")
+	}
+}
+
+func TestDecoder_JSONInvalid(t *testing.T) {
+	data := strings.NewReader(`{,{},[],null`)
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d"})
+
+	testVal := &jsonTest{}
+
+	err := dec.Decode(testVal)
+	if err == nil {
+		t.Error("expected json error")
+	}
+}
+
+func TestDecoder_Bytes(t *testing.T) {
+	data := strings.NewReader("aGVsbG8=,-_8=,deadbeef,raw bytes")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d"})
+
+	testVal := &bytesTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if string(testVal.A) != "hello" {
+		t.Errorf("testVal.A expected %s but got %s", "hello", testVal.A)
+	}
+
+	if !bytes.Equal(testVal.B, []byte{0xfb, 0xff}) {
+		t.Errorf("testVal.B expected %v but got %v", []byte{0xfb, 0xff}, testVal.B)
+	}
+
+	if testVal.C != [4]byte{0xde, 0xad, 0xbe, 0xef} {
+		t.Errorf("testVal.C expected %v but got %v", [4]byte{0xde, 0xad, 0xbe, 0xef}, testVal.C)
+	}
+
+	if string(testVal.D) != "raw bytes" {
+		t.Errorf("testVal.D expected %s but got %s", "raw bytes", testVal.D)
+	}
+}
+
+func TestDecoder_BytesArrayLength(t *testing.T) {
+	data := strings.NewReader(",,dead,")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d"})
+
+	testVal := &bytesTest{}
+
+	err := dec.Decode(testVal)
+	if err != gocsv.ErrInvalidByteLength {
+		t.Error("expected ErrInvalidByteLength")
+	}
+}
+
+func TestDecoder_BytesBadEncoding(t *testing.T) {
+	data := strings.NewReader("abc")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a"})
+
+	testVal := &bytesTestBadEncoding{}
+
+	err := dec.Decode(testVal)
+	if err != gocsv.ErrInvalidEncoding {
+		t.Error("expected ErrInvalidEncoding")
+	}
+}
+
+func TestDecoder_Dynamic(t *testing.T) {
+	data := strings.NewReader("42,1.5,TRUE,2019-03-09T00:00:00Z,,hello")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d", "e", "f"})
+
+	testVal := &dynamicTest{E: "old"}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A != int64(42) {
+		t.Errorf("testVal.A expected %#v but got %#v", int64(42), testVal.A)
+	}
+
+	if testVal.B != 1.5 {
+		t.Errorf("testVal.B expected %#v but got %#v", 1.5, testVal.B)
+	}
+
+	if testVal.C != true {
+		t.Errorf("testVal.C expected %#v but got %#v", true, testVal.C)
+	}
+
+	if d, ok := testVal.D.(time.Time); !ok || !d.Equal(time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("testVal.D expected %s but got %#v", time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC).String(), testVal.D)
+	}
+
+	if testVal.E != nil {
+		t.Errorf("testVal.E expected nil but got %#v", testVal.E)
+	}
+
+	if testVal.F != "hello" {
+		t.Errorf("testVal.F expected %#v but got %#v", "hello", testVal.F)
+	}
+}
+
+func TestDecoder_DynamicMap(t *testing.T) {
+	data := strings.NewReader("string val,1234,NULL")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"str", "n", "x"}).WithNilValue("NULL")
+
+	testVal := map[string]interface{}{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal["str"] != "string val" {
+		t.Errorf("testVal[str] expected string val but got %#v", testVal["str"])
+	}
+
+	if testVal["n"] != int64(1234) {
+		t.Errorf("testVal[n] expected %#v but got %#v", int64(1234), testVal["n"])
+	}
+
+	if v, ok := testVal["x"]; !ok || v != nil {
+		t.Errorf("testVal[x] expected nil but got %#v", v)
+	}
+}
+
+func TestDecoder_DynamicSliceWithInference(t *testing.T) {
+	data := strings.NewReader("1234,true,2019-03-09")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c"}).WithInference(gocsv.Inference{
+		Float:       true,
+		TimeFormats: []string{"2006-01-02"},
+	})
+
+	var testVal []interface{}
+
+	err := dec.Decode(&testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(testVal) != 3 {
+		t.Errorf("testVal len expected 3 but got %d", len(testVal))
+		return
+	}
+
+	if testVal[0] != float64(1234) {
+		t.Errorf("testVal[0] expected %#v but got %#v", float64(1234), testVal[0])
+	}
+
+	if testVal[1] != "true" {
+		t.Errorf("testVal[1] expected %#v but got %#v", "true", testVal[1])
+	}
+
+	if _, ok := testVal[2].(time.Time); !ok {
+		t.Errorf("testVal[2] expected time.Time but got %#v", testVal[2])
+	}
+}
+
+func TestDecoder_TypedMap(t *testing.T) {
+	data := strings.NewReader("12,-3")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"})
+
+	var testVal map[string]int
+
+	err := dec.Decode(&testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal["a"] != 12 {
+		t.Errorf("testVal[a] expected %d but got %d", 12, testVal["a"])
+	}
+
+	if testVal["b"] != -3 {
+		t.Errorf("testVal[b] expected %d but got %d", -3, testVal["b"])
+	}
+}
+
+func TestDecoder_TypedMapError(t *testing.T) {
+	data := strings.NewReader("12,x")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"})
+
+	testVal := map[string]int{}
+
+	err := dec.Decode(testVal)
+	if err == nil {
+		t.Error("expected parse error")
+	}
+}
+
+func TestDecoder_TypedMapValueUnmarshaler(t *testing.T) {
+	data := strings.NewReader("other,")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"})
+
+	testVal := mapValueTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal["a"] == nil || *testVal["a"] != "prefix other" {
+		t.Errorf("testVal[a] expected %s but got %v", "prefix other", testVal["a"])
+	}
+
+	if v, ok := testVal["b"]; !ok || v != nil {
+		t.Errorf("testVal[b] expected nil but got %v", v)
+	}
+}
+
+func TestDecoder_MapPointerNil(t *testing.T) {
+	data := strings.NewReader("string val,1234")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"str", "n"})
+
+	var testVal map[string]string
+
+	err := dec.Decode(&testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal["str"] != "string val" {
+		t.Errorf("testVal[str] expected string val but got %s", testVal["str"])
+	}
+}
+
+func TestDecoder_WithNilValues(t *testing.T) {
+	data := strings.NewReader("NULL,N/A,NULL,\\N")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d"}).WithNilValues("", "NULL", "N/A", "\\N")
+
+	one := 1
+	testVal := &nilTest{A: &one, D: "keep"}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A != nil {
+		t.Errorf("testVal.A expected nil but got %d", *testVal.A)
+	}
+
+	if testVal.B != nil {
+		t.Errorf("testVal.B expected nil but got %s", *testVal.B)
+	}
+
+	if testVal.C == nil || *testVal.C != "NULL" {
+		t.Errorf("testVal.C expected NULL but got %v", testVal.C)
+	}
+
+	if testVal.D != "keep" {
+		t.Errorf("testVal.D expected keep but got %s", testVal.D)
+	}
+}
+
+func TestDecoder_NilTag(t *testing.T) {
+	data := strings.NewReader("1,,-,")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d"})
+
+	testVal := &nilTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A == nil || *testVal.A != 1 {
+		t.Errorf("testVal.A expected 1 but got %v", testVal.A)
+	}
+
+	if testVal.B != nil {
+		t.Errorf("testVal.B expected nil but got %s", *testVal.B)
+	}
+
+	if testVal.C != nil {
+		t.Errorf("testVal.C expected nil but got %s", *testVal.C)
+	}
+}
+
+func TestDecoder_Null(t *testing.T) {
+	data := strings.NewReader("NULL,,x,2019-03-09")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d"}).WithNilValue("NULL")
+
+	testVal := &nullTest{A: gocsv.NewNull(5)}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A.Valid || testVal.A.V != 0 {
+		t.Errorf("testVal.A expected null but got %#v", testVal.A)
+	}
+
+	if !testVal.B.Valid || testVal.B.V != "" {
+		t.Errorf("testVal.B expected valid empty string but got %#v", testVal.B)
+	}
+
+	if !testVal.C.Valid || testVal.C.V != "x" {
+		t.Errorf("testVal.C expected x but got %#v", testVal.C)
+	}
+
+	if !testVal.D.Valid || !testVal.D.V.Equal(time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("testVal.D expected %s but got %#v", time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC).String(), testVal.D)
+	}
+}
+
+func TestDecoder_Normalization(t *testing.T) {
+	data := strings.NewReader(" 42 ,a  b \t c, ,1\u00a0000.5 ,\u200bcafe\u0301, x ")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b", "c", "d", "e", "f"})
+
+	testVal := &normalizeTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A != 42 {
+		t.Errorf("testVal.A expected %d but got %d", 42, testVal.A)
+	}
+
+	if testVal.B != "a b c" {
+		t.Errorf("testVal.B expected %q but got %q", "a b c", testVal.B)
+	}
+
+	if testVal.C != nil {
+		t.Errorf("testVal.C expected nil but got %d", *testVal.C)
+	}
+
+	if testVal.D != 1000.5 {
+		t.Errorf("testVal.D expected %f but got %f", 1000.5, testVal.D)
+	}
+
+	if testVal.E != "caf\u00e9" {
+		t.Errorf("testVal.E expected %q but got %q", "caf\u00e9", testVal.E)
+	}
+
+	if testVal.F != " x " {
+		t.Errorf("testVal.F expected %q but got %q", " x ", testVal.F)
+	}
+}
+
+func TestDecoder_WithNormalization(t *testing.T) {
+	data := strings.NewReader(" 1 ,  NULL ")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"}).WithNilValue("NULL").WithNormalization(gocsv.TrimSpace)
+
+	testVal := map[string]gocsv.Null[int]{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal["a"] != gocsv.NewNull(1) {
+		t.Errorf("testVal[a] expected 1 but got %#v", testVal["a"])
+	}
+
+	if testVal["b"].Valid {
+		t.Errorf("testVal[b] expected null but got %#v", testVal["b"])
+	}
+}
+
+func TestDecoder_NormalizationNFCBeforeNil(t *testing.T) {
+	data := strings.NewReader("e\u0301")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"b"}).WithAllowMissingColumns().WithNilValues("\u00e9").WithNormalization(gocsv.NormalizeNFC)
+
+	s := "old"
+	testVal := &nilTest{B: &s}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.B != nil {
+		t.Errorf("testVal.B expected nil but got %q", *testVal.B)
+	}
+}
+
+func TestDecoder_WithHeaderNormalizer(t *testing.T) {
+	data := strings.NewReader("\ufeffSTR ,N\nthis is a string,12345\n")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithHeaderNormalizer(gocsv.HeaderStripBOM, gocsv.HeaderTrimSpace, gocsv.HeaderLower)
+
+	hdr := dec.Header()
+	if len(hdr) != 2 || hdr[0] != "\ufeffSTR " || hdr[1] != "N" {
+		t.Errorf("hdr expected original names but got %q", hdr)
+	}
+
+	testVal := simpleTest{}
+
+	err := dec.Decode(&testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.StringVal != "this is a string" {
+		t.Errorf("testVal.StringVal expected: %s got: %s", "this is a string", testVal.StringVal)
+	}
+
+	if testVal.IntVal != 12345 {
+		t.Errorf("testVal.IntVal expected: %d got: %d", 12345, testVal.IntVal)
+	}
+}
+
+func TestDecoder_WithHeaderNormalizerCollision(t *testing.T) {
+	decode := func(allowAmbiguous bool) (*simpleTest, error) {
+		r := csv.NewReader(strings.NewReader("a,b,1\n"))
+		dec := gocsv.NewDecoder(r).WithHeader([]string{"STR", "str ", "n"}).WithHeaderNormalizer(gocsv.HeaderTrimSpace, gocsv.HeaderLower)
+		if allowAmbiguous {
+			dec.WithAllowAmbiguousAliases()
+		}
+
+		testVal := &simpleTest{}
+		return testVal, dec.Decode(testVal)
+	}
+
+	_, err := decode(false)
+	if !errors.Is(err, gocsv.ErrAmbiguousColumn) {
+		t.Errorf("expected ErrAmbiguousColumn but got %v", err)
+	}
+
+	testVal, err := decode(true)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.StringVal != "a" || testVal.IntVal != 1 {
+		t.Errorf("testVal expected {a 1} but got %v", *testVal)
+	}
+}
+
+func TestDecoder_HeaderSnakeCase(t *testing.T) {
+	names := map[string]string{
+		"Postal Code":  "postal_code",
+		"postalCode":   "postal_code",
+		"POSTAL-CODE":  "postal_code",
+		"UserIDNumber": "user_id_number",
+		"line2":        "line2",
+		"e-mail (1)":   "e_mail_1",
+	}
+
+	for name, expected := range names {
+		if actual := gocsv.HeaderSnakeCase(name); actual != expected {
+			t.Errorf("HeaderSnakeCase(%q) expected %q but got %q", name, expected, actual)
+		}
+	}
+
+	if actual := gocsv.HeaderRemovePunctuation("e-mail (1)"); actual != "email 1" {
+		t.Errorf("HeaderRemovePunctuation expected %q but got %q", "email 1", actual)
+	}
+}
+
+func TestDecoder_WithHeaderNormalizerMap(t *testing.T) {
+	data := strings.NewReader("string val,1234")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeaderNormalizer(gocsv.HeaderSnakeCase).WithHeader([]string{"String Value", "Number"})
+
+	testVal := map[string]string{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal["string_value"] != "string val" {
+		t.Errorf("testVal[string_value] expected string val but got %s", testVal["string_value"])
+	}
+
+	if testVal["number"] != "1234" {
+		t.Errorf("testVal[number] expected %s but got %s", "1234", testVal["number"])
+	}
+}
+
+func TestDecoder_Aliases(t *testing.T) {
+	data := strings.NewReader("Springfield,12345")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"town", "postal_code"})
+
+	testVal := &aliasTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.Zip != "12345" {
+		t.Errorf("testVal.Zip expected %s but got %s", "12345", testVal.Zip)
+	}
+
+	if testVal.City != "Springfield" {
+		t.Errorf("testVal.City expected %s but got %s", "Springfield", testVal.City)
+	}
+}
+
+func TestDecoder_AliasesAmbiguous(t *testing.T) {
+	data := strings.NewReader("Springfield,12345,54321")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"city", "zip code", "zip"})
+
+	testVal := &aliasTest{}
+
+	err := dec.Decode(testVal)
+	if err != gocsv.ErrAmbiguousColumn {
+		t.Error("expected ErrAmbiguousColumn")
+	}
+}
+
+func TestDecoder_WithAllowAmbiguousAliases(t *testing.T) {
+	data := strings.NewReader("Springfield,12345,54321")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"city", "zip code", "zip"}).WithAllowAmbiguousAliases()
+
+	testVal := &aliasTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.Zip != "54321" {
+		t.Errorf("testVal.Zip expected %s but got %s", "54321", testVal.Zip)
+	}
+}
+
+func TestDecoder_Bindings(t *testing.T) {
+	dec := gocsv.NewDecoder(nil).WithHeader([]string{"e-mail", "postal_code", "notes"})
+
+	b, err := dec.Bindings(bindingsTest{})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(b.Fields) != 1 || b.Fields[0] != (gocsv.FieldBinding{Field: "Zip", Column: "postal_code", Index: 1}) {
+		t.Errorf("b.Fields expected Zip bound to postal_code but got %#v", b.Fields)
+	}
+
+	if len(b.Missing) != 2 {
+		t.Errorf("b.Missing expected 2 columns but got %#v", b.Missing)
+		return
+	}
+
+	if b.Missing[0].Field != "Email" || len(b.Missing[0].Candidates) != 1 || b.Missing[0].Candidates[0] != "e-mail" {
+		t.Errorf("b.Missing[0] expected Email with candidate e-mail but got %#v", b.Missing[0])
+	}
+
+	if b.Missing[1].Field != "Name" || len(b.Missing[1].Candidates) != 0 {
+		t.Errorf("b.Missing[1] expected Name without candidates but got %#v", b.Missing[1])
+	}
+
+	if len(b.Unclaimed) != 2 || b.Unclaimed[0] != "e-mail" || b.Unclaimed[1] != "notes" {
+		t.Errorf("b.Unclaimed expected [e-mail notes] but got %q", b.Unclaimed)
+	}
+}
+
+func TestDecoder_MissingColumnsError(t *testing.T) {
+	data := strings.NewReader("a@example.com,12345")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"Email", "zip"})
+
+	testVal := &bindingsTest{}
+
+	err := dec.Decode(testVal)
+
+	var missing *gocsv.MissingColumnsError
+	if !errors.As(err, &missing) {
+		t.Errorf("expected *gocsv.MissingColumnsError but got %v", err)
+		return
+	}
+
+	expected := `gocsv: missing column in csv: "email" for field Email (did you mean "Email"?); "name" for field Name; bound fields: Zip to "zip"; unclaimed columns: ["Email"]`
+	if err.Error() != expected {
+		t.Errorf("expected: %s got: %s", expected, err.Error())
+	}
+}
+
+func TestDecoder_BindingsShortNames(t *testing.T) {
+	dec := gocsv.NewDecoder(nil).WithHeader([]string{"A", "x"})
+
+	b, err := dec.Bindings(&intTest{})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	for _, m := range b.Missing {
+		if len(m.Candidates) > 0 {
+			t.Errorf("expected no candidates for %s but got %q", m.Column, m.Candidates)
+		}
+	}
+}
+
+func TestDecoder_BindingsChangedHeader(t *testing.T) {
+	data := strings.NewReader("x,1\n2,y\n")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"str", "n"})
+
+	testVal := &simpleTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	dec.WithHeader([]string{"n", "str"})
+
+	err = dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.StringVal != "y" || testVal.IntVal != 2 {
+		t.Errorf("testVal expected {y 2} but got %v", testVal)
+	}
+}
+
+func TestDecoder_NilMap(t *testing.T) {
+	for _, v := range []interface{}{map[string]string(nil), map[string]int(nil)} {
+		r := csv.NewReader(strings.NewReader("1,2\n"))
+		dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"})
+
+		err := dec.Decode(v)
+		if err != gocsv.ErrInvalidType {
+			t.Errorf("expected ErrInvalidType for %T but got %v", v, err)
+		}
+	}
+}
+
+func TestDecoder_MapShortRecord(t *testing.T) {
+	decode := func(v interface{}, allowMissing bool) error {
+		r := csv.NewReader(strings.NewReader("1\n"))
+		r.FieldsPerRecord = -1
+
+		dec := gocsv.NewDecoder(r).WithHeader([]string{"a", "b"})
+		if allowMissing {
+			dec.WithAllowMissingColumns()
+		}
+
+		return dec.Decode(v)
+	}
+
+	for _, v := range []interface{}{map[string]int{}, map[string]string{}, &mapMarshalerTest{}} {
+		err := decode(v, false)
+		if !errors.Is(err, gocsv.ErrMissingColumn) {
+			t.Errorf("expected ErrMissingColumn for %T but got %v", v, err)
+		}
+	}
+
+	m := map[string]int{}
+
+	err := decode(m, true)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(m) != 1 || m["a"] != 1 {
+		t.Errorf("m expected map[a:1] but got %v", m)
+	}
+}
+
+func TestDecoder_BindingsMissingHeader(t *testing.T) {
+	_, err := gocsv.NewDecoder(nil).Bindings(&bindingsTest{})
+	if err != gocsv.ErrMissingHeader {
+		t.Error("expected ErrMissingHeader")
+	}
+}
+
+func TestDecoder_WithTagName(t *testing.T) {
+	data := strings.NewReader("7,bob,x")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"id", "user_name", "Skipped"}).WithTagName("db")
+
+	testVal := &dbTagTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.ID != 7 {
+		t.Errorf("testVal.ID expected %d but got %d", 7, testVal.ID)
+	}
+
+	if testVal.UserName != "bob" {
+		t.Errorf("testVal.UserName expected %s but got %s", "bob", testVal.UserName)
+	}
+
+	if testVal.Skipped != "" {
+		t.Errorf("testVal.Skipped expected empty but got %s", testVal.Skipped)
+	}
+}
+
+func TestDecoder_WithTagNameOptions(t *testing.T) {
+	data := strings.NewReader(",")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"name", "note"}).WithTagName("json")
+
+	name := "old"
+	testVal := &jsonTagTest{Name: &name, Note: "old"}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.Name != nil {
+		t.Errorf("testVal.Name expected nil but got %s", *testVal.Name)
+	}
+
+	if testVal.Note != "old" {
+		t.Errorf("testVal.Note expected %s but got %s", "old", testVal.Note)
+	}
+}
+
+func TestDecoder_WithUntaggedFields(t *testing.T) {
+	data := strings.NewReader("7,Bob,404,x")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"id", "first_name", "http_code", "ignored"}).WithUntaggedFields(gocsv.NameSnakeCase)
+
+	testVal := &untaggedTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.UserID != 7 || testVal.FirstName != "Bob" || testVal.HTTPCode != 404 || testVal.Ignored != "" {
+		t.Errorf("testVal expected {7 Bob 404 } but got %v", *testVal)
+	}
+}
+
+func TestDecoder_NamingStrategies(t *testing.T) {
+	names := []struct {
+		naming   func(string) string
+		expected string
+	}{
+		{gocsv.NameExact, "UserIDNumber"},
+		{gocsv.NameSnakeCase, "user_id_number"},
+		{gocsv.NameKebabCase, "user-id-number"},
+		{gocsv.NameLowerCamel, "userIdNumber"},
+	}
+
+	for _, n := range names {
+		if actual := n.naming("UserIDNumber"); actual != n.expected {
+			t.Errorf("expected %q but got %q", n.expected, actual)
+		}
+	}
+}
+
+func TestDecoder_WithMapping(t *testing.T) {
+	data := strings.NewReader("ab-1,9.5,USD,03/09/2019")
+	r := csv.NewReader(data)
+	m := gocsv.Map[mappedTest]().
+		Column("sku", "SKU").
+		Column("price", "Price.Amount").
+		Column("currency|cur", "Price.Currency").
+		Column("created", "Created", gocsv.Format("01/02/2006"))
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"sku", "price", "cur", "created"}).WithMapping(m)
+
+	testVal := &mappedTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.SKU != "ab-1" {
+		t.Errorf("testVal.SKU expected %s but got %s", "ab-1", testVal.SKU)
+	}
+
+	if testVal.Price == nil || testVal.Price.Amount != 9.5 || testVal.Price.Currency != "USD" {
+		t.Errorf("testVal.Price expected {9.5 USD} but got %v", testVal.Price)
+	}
+
+	if !testVal.Created.Equal(time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("testVal.Created expected %s but got %s", time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC).String(), testVal.Created.String())
+	}
+}
+
+func TestDecoder_WithMappingInvalidField(t *testing.T) {
+	data := strings.NewReader("ab-1")
+	r := csv.NewReader(data)
+	m := gocsv.Map[mappedTest]().Column("sku", "Price.Missing")
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"sku"}).WithMapping(m)
+
+	if !errors.Is(m.Err(), gocsv.ErrInvalidMapping) {
+		t.Errorf("expected ErrInvalidMapping but got %v", m.Err())
+	}
+
+	err := dec.Decode(&mappedTest{})
+	if !errors.Is(err, gocsv.ErrInvalidMapping) {
+		t.Errorf("expected ErrInvalidMapping but got %v", err)
+	}
+}
+
+func TestDecoder_WithMappingZeroValue(t *testing.T) {
+	data := strings.NewReader("ab-1")
+	r := csv.NewReader(data)
+
+	var m gocsv.Mapping[mappedTest]
+	m.Column("sku", "SKU")
+
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"sku"}).WithMapping(&m)
+
+	testVal := &mappedTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.SKU != "ab-1" {
+		t.Errorf("testVal.SKU expected %s but got %s", "ab-1", testVal.SKU)
+	}
+}
+
+func TestDecoder_Default(t *testing.T) {
+	data := strings.NewReader("7,")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"id", "qty"}).WithAllowMissingColumns()
+
+	testVal := &defaultTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.ID != 7 || testVal.Qty != 1 || testVal.Notes != "none" {
+		t.Errorf("testVal expected {7 1 none} but got %v", *testVal)
+	}
+}
+
+func TestDecoder_DefaultMissingColumn(t *testing.T) {
+	data := strings.NewReader("7")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"id"})
+
+	testVal := &defaultTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.ID != 7 || testVal.Qty != 1 || testVal.Notes != "none" {
+		t.Errorf("testVal expected {7 1 none} but got %v", *testVal)
+	}
+}
+
+func TestDecoder_Required(t *testing.T) {
+	data := strings.NewReader("3")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"qty"}).WithAllowMissingColumns()
+
+	testVal := &defaultTest{}
+
+	err := dec.Decode(testVal)
+
+	var missing *gocsv.MissingColumnsError
+	if !errors.As(err, &missing) {
+		t.Errorf("expected *gocsv.MissingColumnsError but got %v", err)
+		return
+	}
+
+	if len(missing.Bindings.Missing) != 2 || !missing.Bindings.Missing[0].Required || missing.Bindings.Missing[1].Required {
+		t.Errorf("expected id to be a required missing column but got %#v", missing.Bindings.Missing)
+	}
+}
+
+func TestDecoder_Positional(t *testing.T) {
+	data := strings.NewReader("x,skip,12,skip,y")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r)
+
+	testVal := &positionalTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.A != "x" || testVal.B != 12 || testVal.C != "y" {
+		t.Errorf("testVal expected {x 12 y} but got %v", *testVal)
+	}
+}
+
+func TestDecoder_PositionalShortLine(t *testing.T) {
+	data := strings.NewReader("x,skip,12")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r)
+
+	testVal := &positionalTest{}
+
+	err := dec.Decode(testVal)
+	if err != gocsv.ErrMissingColumn {
+		t.Errorf("expected ErrMissingColumn but got %v", err)
+	}
+}
+
+func TestDecoder_WithPositionalFields(t *testing.T) {
+	data := strings.NewReader("this is a string,12345\n")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithPositionalFields()
+
+	testVal := simpleTest{}
+
+	err := dec.Decode(&testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.StringVal != "this is a string" {
+		t.Errorf("testVal.StringVal expected: %s got: %s", "this is a string", testVal.StringVal)
+	}
+
+	if testVal.IntVal != 12345 {
+		t.Errorf("testVal.IntVal expected: %d got: %d", 12345, testVal.IntVal)
+	}
+}
@@ -0,0 +1,126 @@
+package gocsv
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Inference controls how the Decoder converts csv values into Go values when decoding into
+// interface{} destinations. Values that match none of the enabled rules are left as strings,
+// and the nil value always becomes nil.
+type Inference struct {
+	// Int converts integers to int64.
+	Int bool
+
+	// Float converts floating point numbers to float64.
+	Float bool
+
+	// Bool converts true and false, in any case, to bool.
+	Bool bool
+
+	// TimeFormats are the layouts tried, in order, to convert a value to time.Time.
+	TimeFormats []string
+}
+
+// DefaultInference is the Inference used by a new Decoder. It enables every rule and
+// recognizes RFC 3339 timestamps.
+var DefaultInference = Inference{
+	Int:         true,
+	Float:       true,
+	Bool:        true,
+	TimeFormats: []string{time.RFC3339},
+}
+
+func (inf Inference) infer(value string) interface{} {
+	if inf.Int {
+		if intVal, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return intVal
+		}
+	}
+
+	if inf.Float {
+		if floatVal, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(floatVal, 0) && !math.IsNaN(floatVal) {
+			return floatVal
+		}
+	}
+
+	if inf.Bool {
+		if strings.EqualFold(value, "true") {
+			return true
+		}
+
+		if strings.EqualFold(value, "false") {
+			return false
+		}
+	}
+
+	for _, format := range inf.TimeFormats {
+		if timeVal, err := time.Parse(format, value); err == nil {
+			return timeVal
+		}
+	}
+
+	return value
+}
+
+func isEmptyInterface(t reflect.Type) bool {
+	return t.Kind() == reflect.Interface && t.NumMethod() == 0
+}
+
+func (dec *Decoder) decodeDynamic(valf reflect.Value, value string, isNil bool) {
+	if isNil {
+		valf.Set(reflect.Zero(valf.Type()))
+		return
+	}
+
+	valf.Set(reflect.ValueOf(dec.inference.infer(value)))
+}
+
+func (dec *Decoder) decodeDynamicSlice(line []string) []interface{} {
+	s := make([]interface{}, len(line))
+	for i, v := range line {
+		v = dec.normalization.apply(v)
+		if !dec.isNil(v, "") {
+			s[i] = dec.inference.infer(v)
+		}
+	}
+	return s
+}
+
+// encodeDynamic formats a value held in an interface{}, such as one produced by the Decoder's
+// type inference.
+func (enc *Encoder) encodeDynamic(v interface{}, tag reflect.StructTag) (string, error) {
+	if v == nil {
+		return enc.nilValue(tag), nil
+	}
+
+	if m, ok := v.(ValueMarshaller); ok {
+		return m.MarshalCSVValue(), nil
+	}
+
+	valf := reflect.ValueOf(v)
+	if valf.Kind() == reflect.Ptr {
+		if valf.IsNil() {
+			return enc.nilValue(tag), nil
+		}
+
+		valf = valf.Elem()
+	}
+
+	return encodeValue(valf, tag)
+}
+
+func (enc *Encoder) encodeDynamicSlice(s []interface{}) error {
+	line := make([]string, len(s))
+	for i, v := range s {
+		str, err := enc.encodeDynamic(v, "")
+		if err != nil {
+			return err
+		}
+		line[i] = str
+	}
+	return enc.write(line)
+}
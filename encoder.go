@@ -17,9 +17,15 @@ type Writer interface {
 // Encoder is used to encode structs to a csv.
 type Encoder struct {
 	w                   Writer
+	header              []string
 	hdr                 map[string]int
 	nilVal              string
 	allowMissingColumns bool
+	fields              fieldOptions
+	autoHeader          bool
+	headerWritten       bool
+	row                 int
+	beforeHooks         []func(v interface{}) error
 }
 
 // ValueMarshaller is any type that can marshal it's own csv value.
@@ -32,6 +38,19 @@ type Marshaler interface {
 	MarshalCSV() ([]string, error)
 }
 
+// MapMarshaler can be implemented by your structs for custom marshaling logic. The Encoder
+// writes the values in the order of its header, and the nil value for missing keys.
+type MapMarshaler interface {
+	MarshalCSVMap() (map[string]string, error)
+}
+
+// HeaderMarshaler can be implemented by your types to declare their columns. The Encoder uses
+// them as its header when it has none, and writes them as the header line with
+// WithAutoHeader.
+type HeaderMarshaler interface {
+	MarshalCSVHeader() []string
+}
+
 // NewEncoder creates a new Encoder.
 func NewEncoder(w Writer) *Encoder {
 	return &Encoder{
@@ -43,6 +62,8 @@ func NewEncoder(w Writer) *Encoder {
 // if you want to write the csv columns in an order other than the default
 // order of the struct.
 func (enc *Encoder) WithHeader(h []string) *Encoder {
+	enc.header = append([]string(nil), h...)
+
 	hdr := map[string]int{}
 
 	for i, v := range h {
@@ -54,19 +75,79 @@ func (enc *Encoder) WithHeader(h []string) *Encoder {
 	return enc
 }
 
-// WithNilValue sets the string to use for a value if it is nil.
+// WithNilValue sets the string to use for a value if it is nil. A field can override this with
+// a nil struct tag, like `nil:"NULL"`.
 func (enc *Encoder) WithNilValue(val string) *Encoder {
 	enc.nilVal = val
 	return enc
 }
 
 // WithAllowMissingColumns prevents the Encoder from returning an error if a column
-// defined in the struct is missing from the header.
+// defined in the struct is missing from the header, unless the field has the required option.
 func (enc *Encoder) WithAllowMissingColumns() *Encoder {
 	enc.allowMissingColumns = true
 	return enc
 }
 
+// WithTagName makes the Encoder read column names from a struct tag other than csv, such as db
+// or json. Only the name is read from that tag: csv options like omitempty are still read from
+// the csv tag, and options like format and nil from their own struct tags.
+func (enc *Encoder) WithTagName(name string) *Encoder {
+	enc.fields.tagName = name
+	return enc
+}
+
+// WithUntaggedFields maps exported struct fields without a column name in their tag to the
+// column named by naming, such as NameSnakeCase. Fields tagged "-" are still skipped.
+func (enc *Encoder) WithUntaggedFields(naming func(string) string) *Encoder {
+	enc.fields.naming = naming
+	return enc
+}
+
+// WithMapping registers a Mapping, built with Map, that replaces the struct tags of its type.
+func (enc *Encoder) WithMapping(m Mapper) *Encoder {
+	enc.fields.addMapping(m.csvMapping())
+	return enc
+}
+
+// WithPositionalFields writes the fields of a struct to columns in declaration order instead
+// of by name. Fields can also be written to a zero-based column index individually with a tag
+// like `csv:"#3"`. Columns between positional fields are left blank.
+func (enc *Encoder) WithPositionalFields() *Encoder {
+	enc.fields.positional = true
+	return enc
+}
+
+// WithAutoHeader makes the Encoder write the header line before the first record.
+func (enc *Encoder) WithAutoHeader() *Encoder {
+	enc.autoHeader = true
+	return enc
+}
+
+// WriteHeader writes the header line. The header is the one given to WithHeader, or the one
+// the Encoder built from the first value encoded.
+func (enc *Encoder) WriteHeader() error {
+	if len(enc.header) == 0 {
+		return ErrMissingHeader
+	}
+
+	enc.headerWritten = true
+
+	return enc.w.Write(enc.header)
+}
+
+// write writes line, preceded by the header line the first time when WithAutoHeader is used.
+func (enc *Encoder) write(line []string) error {
+	if enc.autoHeader && !enc.headerWritten && len(enc.header) > 0 {
+		err := enc.WriteHeader()
+		if err != nil {
+			return err
+		}
+	}
+
+	return enc.w.Write(line)
+}
+
 // Flush will flush the underlying writer.
 func (enc *Encoder) Flush() {
 	enc.w.Flush()
@@ -74,12 +155,49 @@ func (enc *Encoder) Flush() {
 
 // Encode converts v to a csv, calling MarshalCSV if v implements Marshaler or
 // using reflection and any csv struct tags. If a field does not have a csv tag,
-// it will be skipped.
+// it will be skipped. When the Encoder builds the header itself, a csv tag with aliases, like
+// `csv:"zip|postal_code"`, is written with its primary name.
+//
+// Fields tagged with the json option, such as `csv:"meta,json"`, are written as compact JSON.
+// Fields tagged with the omitzero option, such as `csv:"n,omitzero"`, are written as the nil
+// value when they hold the zero value for their type.
+//
+// If the Encoder has no header and v implements HeaderMarshaler, its columns become the
+// header. A MapMarshaler is written in the order of the header.
+//
+// A map[string]T is written in the order of the header, formatting each value the same way as
+// a struct field of type T. Keys missing from the map are written as the nil value.
+//
+// Values held in a map[string]interface{}, a []interface{}, or a struct field of type
+// interface{} are formatted according to their dynamic type, with nil written as the nil value.
+//
+// Before v is encoded, its BeforeEncodeCSV method runs, then the functions given to
+// WithBeforeEncode. Their errors are returned as a *RowError.
 func (enc *Encoder) Encode(v interface{}) error {
+	if h, ok := v.(HeaderMarshaler); ok && len(enc.header) == 0 {
+		enc.WithHeader(h.MarshalCSVHeader())
+	}
+
+	enc.row++
+
+	err := enc.beforeEncode(v)
+	if err != nil {
+		return err
+	}
+
+	return enc.encode(v)
+}
+
+// encode writes v as a record.
+func (enc *Encoder) encode(v interface{}) error {
 	if m, ok := v.(Marshaler); ok {
 		return enc.encodeMarshaler(m)
 	}
 
+	if m, ok := v.(MapMarshaler); ok {
+		return enc.encodeMapMarshaler(m)
+	}
+
 	if m, ok := v.(map[string]string); ok {
 		return enc.encodeMap(m)
 	}
@@ -88,7 +206,19 @@ func (enc *Encoder) Encode(v interface{}) error {
 		return enc.encodeMap(*m)
 	}
 
+	if s, ok := v.([]interface{}); ok {
+		return enc.encodeDynamicSlice(s)
+	}
+
+	if s, ok := v.(*[]interface{}); ok {
+		return enc.encodeDynamicSlice(*s)
+	}
+
 	t := reflect.TypeOf(v)
+	if isStringMap(t) {
+		return enc.encodeTypedMap(reflect.ValueOf(v))
+	}
+
 	if t.Kind() != reflect.Ptr {
 		return ErrInvalidType
 	}
@@ -96,127 +226,231 @@ func (enc *Encoder) Encode(v interface{}) error {
 	t = t.Elem()
 	val := reflect.Indirect(reflect.ValueOf(v))
 
-	if len(enc.hdr) == 0 {
-		enc.buildHeader(t)
+	if isStringMap(t) {
+		return enc.encodeTypedMap(val)
 	}
 
-	line := make([]string, len(enc.hdr))
+	if t.Kind() != reflect.Struct {
+		return ErrInvalidType
+	}
 
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		tag, _ := parseTag(f.Tag.Get("csv"))
-		if tag == "" || tag == "-" {
-			continue
+	fields, err := enc.fields.fieldsOf(t)
+	if err != nil {
+		return err
+	}
+
+	if len(enc.header) == 0 {
+		enc.buildHeader(fields)
+	}
+
+	line := make([]string, len(enc.header))
+	owners := make([]string, len(enc.header))
+
+	for i, f := range fields {
+		column := f.names[0]
+
+		index, ok := enc.fields.position(i, f)
+		if !ok {
+			column, index, ok = enc.columnIndex(f.names)
 		}
 
-		index, ok := enc.hdr[tag]
 		if !ok {
-			if enc.allowMissingColumns {
+			if enc.allowMissingColumns && !hasOption(f.tagOptions, "required") {
 				continue
-			} else {
-				return ErrMissingColumn
 			}
+
+			return ErrMissingColumn
 		}
 
-		valf := val.FieldByName(f.Name)
-		kind := f.Type.Kind()
+		for len(line) <= index {
+			line = append(line, "")
+			owners = append(owners, "")
+		}
 
-		if kind == reflect.Ptr {
-			if valf.IsNil() {
-				line[index] = enc.nilVal
-				continue
-			}
+		if owners[index] != "" {
+			return fmt.Errorf("%w: fields %s and %s are both bound to column %d", ErrDuplicateColumn, owners[index], f.name, index)
 		}
+		owners[index] = f.name
 
-		if m, ok := valf.Addr().Interface().(ValueMarshaller); ok {
-			line[index] = m.MarshalCSVValue()
+		valf, ok := fieldByIndex(val, f.index, false)
+		if !ok {
+			line[index] = enc.nilValue(f.tag)
 			continue
 		}
 
-		if m, ok := valf.Interface().(ValueMarshaller); ok {
-			line[index] = m.MarshalCSVValue()
-			continue
+		str, err := enc.encodeField(valf, enc.fieldContext(column, f.name, f.tag), f.tagOptions)
+		if err != nil {
+			return err
 		}
+		line[index] = str
+	}
 
-		if kind == reflect.Ptr {
-			kind = f.Type.Elem().Kind()
-			valf = reflect.Indirect(valf)
-		}
-
-		switch kind {
-		case reflect.String:
-			line[index] = valf.String()
-		case reflect.Bool:
-			line[index] = strconv.FormatBool(valf.Bool())
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			if baseStr, ok := f.Tag.Lookup("base"); ok {
-				base, err := strconv.ParseInt(baseStr, 10, 32)
-				if err != nil {
-					return ErrInvalidIntBase
-				}
-				line[index] = strconv.FormatInt(valf.Int(), int(base))
-			} else {
-				line[index] = strconv.FormatInt(valf.Int(), 10)
+	return enc.write(line)
+}
+
+// encodeValue formats valf according to its kind and any options in tag.
+func encodeValue(valf reflect.Value, tag reflect.StructTag) (string, error) {
+	switch valf.Kind() {
+	case reflect.String:
+		return valf.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(valf.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if baseStr, ok := tag.Lookup("base"); ok {
+			base, err := strconv.ParseInt(baseStr, 10, 32)
+			if err != nil {
+				return "", ErrInvalidIntBase
 			}
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			if baseStr, ok := f.Tag.Lookup("base"); ok {
-				base, err := strconv.ParseInt(baseStr, 10, 32)
-				if err != nil {
-					return ErrInvalidIntBase
-				}
-				line[index] = strconv.FormatUint(valf.Uint(), int(base))
-			} else {
-				line[index] = strconv.FormatUint(valf.Uint(), 10)
+			return strconv.FormatInt(valf.Int(), int(base)), nil
+		}
+
+		return strconv.FormatInt(valf.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if baseStr, ok := tag.Lookup("base"); ok {
+			base, err := strconv.ParseInt(baseStr, 10, 32)
+			if err != nil {
+				return "", ErrInvalidIntBase
 			}
-		case reflect.Float32, reflect.Float64:
-			format := f.Tag.Get("format")
-			if format == "" {
-				precisionStr := f.Tag.Get("precision")
-				if precisionStr == "" {
-					precisionStr = "-1"
-				}
-
-				precision, err := strconv.ParseInt(precisionStr, 10, 32)
-				if err != nil {
-					return ErrInvalidFloatPrecision
-				}
-
-				line[index] = strconv.FormatFloat(valf.Float(), 'f', int(precision), 64)
-			} else {
-				line[index] = fmt.Sprintf(format, valf.Float())
+			return strconv.FormatUint(valf.Uint(), int(base)), nil
+		}
+
+		return strconv.FormatUint(valf.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		format := tag.Get("format")
+		if format == "" {
+			precisionStr := tag.Get("precision")
+			if precisionStr == "" {
+				precisionStr = "-1"
 			}
-		case reflect.Struct:
-			if valf.Type() == reflect.TypeOf(time.Time{}) {
-				format := f.Tag.Get("format")
-				if format == "" {
-					format = time.RFC3339
-				}
-
-				line[index] = valf.Interface().(time.Time).Format(format)
-			} else {
-				return ErrInvalidDestType
+
+			precision, err := strconv.ParseInt(precisionStr, 10, 32)
+			if err != nil {
+				return "", ErrInvalidFloatPrecision
 			}
-		default:
-			return ErrInvalidDestType
+
+			return strconv.FormatFloat(valf.Float(), 'f', int(precision), 64), nil
+		}
+
+		return fmt.Sprintf(format, valf.Float()), nil
+	case reflect.Slice:
+		if !isByteSlice(valf.Type()) {
+			return "", invalidDestType(valf.Type())
+		}
+
+		return encodeBytes(tag, valf.Bytes())
+	case reflect.Array:
+		if !isByteArray(valf.Type()) {
+			return "", invalidDestType(valf.Type())
 		}
+
+		b := make([]byte, valf.Len())
+		reflect.Copy(reflect.ValueOf(b), valf)
+
+		return encodeBytes(tag, b)
+	case reflect.Struct:
+		if isTime(valf.Type()) {
+			format := tag.Get("format")
+			if format == "" {
+				format = time.RFC3339
+			}
+
+			return valf.Convert(timeType).Interface().(time.Time).Format(format), nil
+		}
+
+		return "", invalidDestType(valf.Type())
+	default:
+		return "", invalidDestType(valf.Type())
 	}
+}
 
-	return enc.w.Write(line)
+// nilValue returns the string written for a nil field, taken from the first value of its nil
+// struct tag if present and from the Encoder otherwise.
+func (enc *Encoder) nilValue(tag reflect.StructTag) string {
+	if nilVals, ok := nilValues(tag); ok {
+		return nilVals[0]
+	}
+
+	return enc.nilVal
 }
 
-func (enc *Encoder) buildHeader(t reflect.Type) {
-	hdr := map[string]int{}
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		tag, _ := parseTag(f.Tag.Get("csv"))
-		if tag == "" || tag == "-" {
+// encodeField formats valf, handling nil pointers and pointers to pointers, the omitzero and json
+// options, interface{} values, FieldMarshaler, and ValueMarshaller before falling back to
+// encodeValue.
+func (enc *Encoder) encodeField(valf reflect.Value, ctx FieldContext, tagOptions []string) (string, error) {
+	tag := ctx.Tag
+
+	if hasOption(tagOptions, "omitzero") && valf.IsZero() {
+		return enc.nilValue(tag), nil
+	}
+
+	for valf.Kind() == reflect.Ptr {
+		if valf.IsNil() {
+			return enc.nilValue(tag), nil
+		}
+
+		valf = valf.Elem()
+	}
+
+	if n, ok := asNullable(valf); ok {
+		if !n.csvValid() {
+			return enc.nilValue(tag), nil
+		}
+
+		return enc.encodeField(n.csvValue(), ctx, tagOptions)
+	}
+
+	if hasOption(tagOptions, "json") {
+		return encodeJSON(valf, enc.nilValue(tag))
+	}
+
+	if isEmptyInterface(valf.Type()) {
+		return enc.encodeDynamic(valf.Interface(), tag)
+	}
+
+	m := methodsOf(valf.Type())
+
+	if m.fieldMarshaler {
+		return valf.Addr().Interface().(FieldMarshaler).MarshalCSVField(ctx)
+	}
+
+	if m.valueMarshaller {
+		return valf.Addr().Interface().(ValueMarshaller).MarshalCSVValue(), nil
+	}
+
+	return encodeValue(valf, tag)
+}
+
+// buildHeader builds the header from the primary names of fields bound by name. They follow the
+// columns of the fields bound by position, whose names are left empty.
+func (enc *Encoder) buildHeader(fields []field) {
+	var names []string
+	positional := 0
+
+	for i, f := range fields {
+		if pos, ok := enc.fields.position(i, f); ok {
+			positional = max(positional, pos+1)
 			continue
 		}
 
-		hdr[tag] = i
+		names = append(names, f.names[0])
 	}
 
-	enc.hdr = hdr
+	if len(names) == 0 {
+		return
+	}
+
+	enc.WithHeader(append(make([]string, positional), names...))
+}
+
+// columnIndex returns the first of names present in the header, along with its index.
+func (enc *Encoder) columnIndex(names []string) (string, int, bool) {
+	for _, name := range names {
+		if i, ok := enc.hdr[name]; ok {
+			return name, i, true
+		}
+	}
+
+	return "", 0, false
 }
 
 func (enc *Encoder) encodeMarshaler(m Marshaler) error {
@@ -225,17 +459,68 @@ func (enc *Encoder) encodeMarshaler(m Marshaler) error {
 		return err
 	}
 
-	return enc.w.Write(line)
+	return enc.write(line)
+}
+
+func (enc *Encoder) encodeTypedMap(m reflect.Value) error {
+	if len(enc.header) == 0 {
+		return ErrMissingHeader
+	}
+
+	keyType := m.Type().Key()
+	elemType := m.Type().Elem()
+
+	line := make([]string, len(enc.header))
+	for i, k := range enc.header {
+		elem := m.MapIndex(reflect.ValueOf(k).Convert(keyType))
+		if !elem.IsValid() {
+			line[i] = enc.nilVal
+			continue
+		}
+
+		// Copy the value so that ValueMarshaller implementations with pointer receivers are
+		// found, since map elements are not addressable.
+		addressable := reflect.New(elemType).Elem()
+		addressable.Set(elem)
+
+		str, err := enc.encodeField(addressable, enc.fieldContext(k, "", ""), nil)
+		if err != nil {
+			return err
+		}
+		line[i] = str
+	}
+	return enc.write(line)
+}
+
+func (enc *Encoder) encodeMapMarshaler(m MapMarshaler) error {
+	values, err := m.MarshalCSVMap()
+	if err != nil {
+		return err
+	}
+
+	if len(enc.header) == 0 {
+		return ErrMissingHeader
+	}
+
+	line := make([]string, len(enc.header))
+	for i, k := range enc.header {
+		v, ok := values[k]
+		if !ok {
+			v = enc.nilVal
+		}
+		line[i] = v
+	}
+	return enc.write(line)
 }
 
 func (enc *Encoder) encodeMap(m map[string]string) error {
-	if len(enc.hdr) == 0 {
+	if len(enc.header) == 0 {
 		return ErrMissingHeader
 	}
 
-	line := make([]string, len(enc.hdr))
-	for k, i := range enc.hdr {
+	line := make([]string, len(enc.header))
+	for i, k := range enc.header {
 		line[i] = m[k]
 	}
-	return enc.w.Write(line)
+	return enc.write(line)
 }
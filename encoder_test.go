@@ -2,6 +2,7 @@ package gocsv_test
 
 import (
 	"encoding/csv"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -598,7 +599,7 @@ func TestEncoder_InvalidSlice(t *testing.T) {
 	enc := gocsv.NewEncoder(csvw)
 
 	err := enc.Encode(val)
-	if err != gocsv.ErrInvalidDestType {
+	if !errors.Is(err, gocsv.ErrInvalidDestType) {
 		t.Error("expected ErrInvalidDestType")
 		t.FailNow()
 	}
@@ -614,7 +615,7 @@ func TestEncoder_InvalidType(t *testing.T) {
 	enc := gocsv.NewEncoder(csvw)
 
 	err := enc.Encode(val)
-	if err != gocsv.ErrInvalidDestType {
+	if !errors.Is(err, gocsv.ErrInvalidDestType) {
 		t.Error("expected ErrInvalidDestType")
 		t.FailNow()
 	}
@@ -628,8 +629,767 @@ func TestEncoder_InvalidStruct(t *testing.T) {
 	enc := gocsv.NewEncoder(csvw)
 
 	err := enc.Encode(val)
-	if err != gocsv.ErrInvalidDestType {
+	if !errors.Is(err, gocsv.ErrInvalidDestType) {
 		t.Error("expected ErrInvalidDestType")
 		t.FailNow()
 	}
 }
+
+func TestEncoder_JSON(t *testing.T) {
+	val := &jsonTest{
+		A: jsonMeta{K: 1},
+		B: map[string]string{"x": "y"},
+		C: []int{1, 2, 3},
+	}
+
+	expected := `"{""k"":1}","{""x"":""y""}","[1,2,3]",NULL` + "\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithNilValue("NULL")
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_JSONNoHTMLEscape(t *testing.T) {
+	val := &jsonTest{
+		B: map[string]string{"x": "a<b&c>"},
+	}
+
+	expected := `"{""k"":0}","{""x"":""a<b&c>""}",,` + "\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_Bytes(t *testing.T) {
+	val := &bytesTest{
+		A: []byte("hello"),
+		B: []byte{0xfb, 0xff},
+		C: [4]byte{0xde, 0xad, 0xbe, 0xef},
+		D: []byte("raw bytes"),
+	}
+
+	expected := "aGVsbG8=,-_8=,deadbeef,raw bytes\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_BytesBadEncoding(t *testing.T) {
+	val := &bytesTestBadEncoding{
+		A: []byte("abc"),
+	}
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.Encode(val)
+	if err != gocsv.ErrInvalidEncoding {
+		t.Error("expected ErrInvalidEncoding")
+	}
+}
+
+func TestEncoder_Dynamic(t *testing.T) {
+	val := &dynamicTest{
+		A: int64(42),
+		B: 1.5,
+		C: true,
+		D: time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC),
+		F: "hello",
+	}
+
+	expected := "42,1.5,true,2019-03-09T00:00:00Z,NULL,hello\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithNilValue("NULL")
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_DynamicMap(t *testing.T) {
+	val := map[string]interface{}{
+		"str": "this is a string",
+		"n":   int64(12345),
+	}
+
+	expected := "12345,this is a string,\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"n", "str", "x"})
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_DynamicSlice(t *testing.T) {
+	val := []interface{}{"a", uint8(7), 2.25, nil}
+
+	expected := "a,7,2.25,\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_TypedMap(t *testing.T) {
+	val := map[string]float64{
+		"a": 1.5,
+		"b": -2,
+	}
+
+	expected := "-2,1.5,\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"b", "a", "c"})
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_TypedMapValueMarshaller(t *testing.T) {
+	other := valueMarshaller("other")
+	val := &mapValueTest{
+		"a": &other,
+		"b": nil,
+	}
+
+	expected := "prefix: other,NULL\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"a", "b"}).WithNilValue("NULL")
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_TypedMapNoHeader(t *testing.T) {
+	val := map[string]int{"a": 1}
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.Encode(val)
+	if err != gocsv.ErrMissingHeader {
+		t.Error("expected ErrMissingHeader")
+	}
+}
+
+func TestEncoder_OmitZero(t *testing.T) {
+	val := &omitZeroTest{}
+
+	expected := "NULL,N/A,NULL,\\N\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithNilValue("NULL")
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_OmitZeroNonZero(t *testing.T) {
+	d := 0
+	val := &omitZeroTest{A: 1, B: "b", C: 0.5, D: &d}
+
+	expected := "1,b,0.5,0\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithNilValue("NULL")
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_Null(t *testing.T) {
+	val := &nullTest{
+		B: gocsv.NewNull(""),
+		C: gocsv.NewNull("x"),
+		D: gocsv.NewNull(time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC)),
+	}
+
+	expected := "NULL,,x,2019-03-09\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithNilValue("NULL")
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_Aliases(t *testing.T) {
+	val := &aliasTest{
+		Zip:  "12345",
+		City: "Springfield",
+	}
+
+	expected := "Springfield,12345\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"town", "zip"})
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_WithTagName(t *testing.T) {
+	val := &dbTagTest{
+		ID:        7,
+		UserName:  "bob",
+		Skipped:   "x",
+		CreatedAt: "now",
+	}
+
+	expected := "7,bob,now\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithTagName("db").WithUntaggedFields(gocsv.NameKebabCase)
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_WithUntaggedFields(t *testing.T) {
+	val := &untaggedTest{
+		UserID:    7,
+		FirstName: "Bob",
+		HTTPCode:  404,
+		Ignored:   "x",
+	}
+
+	expected := "Bob,404,7\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"firstName", "httpCode", "id"}).WithUntaggedFields(gocsv.NameLowerCamel)
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_WithMapping(t *testing.T) {
+	val := &mappedTest{
+		SKU:     "ab-1",
+		Price:   &mappedPrice{Amount: 9.5, Currency: "USD"},
+		Created: time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC),
+	}
+
+	expected := "ab-1,9.50,USD,2019-03-09\nab-1,NULL,NULL,2019-03-09\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	m := gocsv.Map[mappedTest]().
+		Column("sku", "SKU").
+		Column("price", "Price.Amount", gocsv.Precision(2)).
+		Column("currency", "Price.Currency").
+		Column("created", "Created", gocsv.Format("2006-01-02"))
+	enc := gocsv.NewEncoder(csvw).WithMapping(m).WithNilValue("NULL")
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	val.Price = nil
+
+	err = enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_Positional(t *testing.T) {
+	val := &positionalTest{
+		A: "x",
+		B: 12,
+		C: "y",
+	}
+
+	expected := "x,,12,,y\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_WithPositionalFields(t *testing.T) {
+	val := &simpleTest{
+		StringVal: "this is a string",
+		IntVal:    12345,
+	}
+
+	expected := "this is a string,12345\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithPositionalFields()
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_HeaderMarshaler(t *testing.T) {
+	val := &headerMarshalerTest{
+		StringVal: "this is a string",
+		OtherVal:  12345,
+	}
+
+	expected := "str,n\nthis is a string,12345\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithAutoHeader()
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_MapMarshalerWithHeader(t *testing.T) {
+	val := &headerMarshalerTest{
+		StringVal: "this is a string",
+		OtherVal:  12345,
+	}
+
+	expected := "12345,this is a string,\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"n", "str", "other"})
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_WithAutoHeader(t *testing.T) {
+	vals := []*simpleTest{
+		{StringVal: "a", IntVal: 1},
+		{StringVal: "b", IntVal: 2},
+	}
+
+	expected := "str,n\na,1\nb,2\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithAutoHeader()
+
+	for _, val := range vals {
+		err := enc.Encode(val)
+		if err != nil {
+			t.Error(err.Error())
+			return
+		}
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_WriteHeaderMissing(t *testing.T) {
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.WriteHeader()
+	if !errors.Is(err, gocsv.ErrMissingHeader) {
+		t.Errorf("expected ErrMissingHeader but got %v", err)
+	}
+}
+
+func TestEncoder_FieldMarshaler(t *testing.T) {
+	height := fieldMarshaller("180")
+	val := &fieldMarshalerTest{
+		Weight: "80",
+		Height: &height,
+	}
+
+	expected := "kg mass:80,cm height:180\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"mass", "height"})
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_FieldMarshalerError(t *testing.T) {
+	val := &fieldMarshalerTest{}
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.Encode(val)
+	if err == nil || err.Error() != "weight is required" {
+		t.Errorf("expected weight is required but got %v", err)
+	}
+}
+
+func TestEncoder_PointerPointer(t *testing.T) {
+	a := 12
+	pa := &a
+	b := valueMarshaller("val")
+	pb := &b
+
+	val := &pointerPointerTest{
+		A: &pa,
+		B: &pb,
+	}
+
+	expected := "12,prefix: val\n"
+
+	buf := strings.Builder{}
+	csvw := csv.NewWriter(&buf)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"a", "b"})
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := buf.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_NamedTime(t *testing.T) {
+	d := time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	val := &namedTimeTest{
+		A: date(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
+		B: (*date)(&d),
+	}
+
+	expected := "2020-01-02,2021-03-04\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"a", "b"})
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_WriteHeaderDuplicates(t *testing.T) {
+	expected := "a,a,b\n1,1,2\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"a", "a", "b"}).WithAutoHeader()
+
+	err := enc.Encode(map[string]string{"a": "1", "b": "2"})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_MixedPositional(t *testing.T) {
+	val := &mixedPositionalTest{A: "a", B: "b", C: "c"}
+
+	expected := ",,b\na,c,b\n"
+
+	buf := strings.Builder{}
+	csvw := csv.NewWriter(&buf)
+	enc := gocsv.NewEncoder(csvw).WithAutoHeader()
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := buf.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_MixedPositionalCollision(t *testing.T) {
+	val := &mixedPositionalTest{A: "a", B: "b", C: "c"}
+
+	buf := strings.Builder{}
+	csvw := csv.NewWriter(&buf)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"b"})
+
+	err := enc.Encode(val)
+	if !errors.Is(err, gocsv.ErrDuplicateColumn) {
+		t.Errorf("expected ErrDuplicateColumn but got %v", err)
+	}
+}
@@ -1,5 +1,11 @@
 package gocsv
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // Error represents any error that can be returned by the gocsv package.
 type Error string
 
@@ -20,17 +26,184 @@ const (
 	ErrInvalidType = Error("gocsv: invalid type; must be a pointer")
 
 	// ErrMissingColumn is returned if your CSV doesn't contain a field specified in the struct.
+	// Decode wraps it in a *MissingColumnsError describing every missing column.
 	ErrMissingColumn = Error("gocsv: missing column in csv")
 
 	// ErrInvalidDestType is returned if you try to Encode or Decode a column that is not a simple type.
-	// Valid types are string, all varieties of int, float, bool, and time.Time
+	// Valid types are string, all varieties of int, float, bool, []byte, [N]byte, and time.Time
 	ErrInvalidDestType = Error("gocsv: invalid destination type; must be a simple type or time.Time")
 
 	// ErrMissingHeader is returned when you try to Decode to a struct, but the Decoder doesn't have a valid
 	// header yet. Use WithHeader or ReadHeader to let the Decoder know what the file looks like.
 	ErrMissingHeader = Error("gocsv: missing header; use WithHeader or ReadHeader functions first")
 
-	// ErrNonPointerReceiver is returned when you have implemented ValueUnmarshaler with a non-pointer
-	// receiver.
+	// ErrNonPointerReceiver is returned when you have implemented ValueUnmarshaler or FieldUnmarshaler
+	// with a non-pointer receiver on a type that holds no references, such as a string or a plain
+	// struct, so the method cannot modify the value. Value receivers are allowed on maps, pointers,
+	// slices, and structs holding them.
 	ErrNonPointerReceiver = Error("gocsv: reciever for ValueUnmarshaler must be a pointer")
+
+	// ErrAmbiguousColumn is returned during decoding if more than one of the aliases in a csv tag
+	// is present in the header, or if its column appears more than once in the normalized
+	// header. Use WithAllowAmbiguousAliases to bind the first one instead.
+	ErrAmbiguousColumn = Error("gocsv: more than one alias for a field is present in the header")
+
+	// ErrInvalidMapping is returned if a Mapping refers to a field that does not exist.
+	ErrInvalidMapping = Error("gocsv: invalid mapping")
+
+	// ErrInvalidSchema is returned if a Schema cannot be parsed or does not match its target type.
+	ErrInvalidSchema = Error("gocsv: invalid schema")
+
+	// ErrInvalidEncoding is returned if the encoding in the struct tag of a []byte or [N]byte field
+	// is not one of base64, base64url, hex, or raw.
+	ErrInvalidEncoding = Error("gocsv: invalid encoding in struct tag")
+
+	// ErrInvalidByteLength is returned during decoding if the decoded bytes for a [N]byte field are
+	// not exactly N bytes long.
+	ErrInvalidByteLength = Error("gocsv: decoded value does not match byte array length")
+
+	// ErrDuplicateColumn is returned during encoding if more than one field of a struct is bound to
+	// the same column.
+	ErrDuplicateColumn = Error("gocsv: more than one field is bound to the same column")
+
+	// ErrTooManyErrors is returned by Decode when more records fail to decode than the budget given
+	// to WithMaxErrors allows.
+	ErrTooManyErrors = Error("gocsv: too many records failed to decode")
 )
+
+// MissingColumnsError is returned by Decode when the header is missing columns for fields of the
+// struct. It matches ErrMissingColumn with errors.Is, and its message reports the Bindings in
+// full: every missing column along with the closest header columns, the columns the other
+// fields are bound to, and the header columns no field claims.
+type MissingColumnsError struct {
+	Bindings *Bindings
+}
+
+func (err *MissingColumnsError) Error() string {
+	var b strings.Builder
+	b.WriteString(string(ErrMissingColumn))
+
+	for i, m := range err.Bindings.Missing {
+		if i == 0 {
+			b.WriteString(": ")
+		} else {
+			b.WriteString("; ")
+		}
+
+		fmt.Fprintf(&b, "%q for field %s", m.Column, m.Field)
+
+		if len(m.Candidates) > 0 {
+			quoted := make([]string, len(m.Candidates))
+			for j, c := range m.Candidates {
+				quoted[j] = fmt.Sprintf("%q", c)
+			}
+
+			fmt.Fprintf(&b, " (did you mean %s?)", strings.Join(quoted, " or "))
+		}
+	}
+
+	if len(err.Bindings.Fields) > 0 {
+		bound := make([]string, len(err.Bindings.Fields))
+		for i, f := range err.Bindings.Fields {
+			bound[i] = fmt.Sprintf("%s to %q", f.Field, f.Column)
+		}
+
+		fmt.Fprintf(&b, "; bound fields: %s", strings.Join(bound, ", "))
+	}
+
+	if len(err.Bindings.Unclaimed) > 0 {
+		fmt.Fprintf(&b, "; unclaimed columns: %q", err.Bindings.Unclaimed)
+	}
+
+	return b.String()
+}
+
+// Unwrap returns ErrMissingColumn.
+func (err *MissingColumnsError) Unwrap() error {
+	return ErrMissingColumn
+}
+
+// RowError is returned when a hook fails for a record. Row is the number of the record, starting
+// at 1 and not counting the header.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (err *RowError) Error() string {
+	return fmt.Sprintf("gocsv: row %d: %v", err.Row, err.Err)
+}
+
+// Unwrap returns the error of the hook.
+func (err *RowError) Unwrap() error {
+	return err.Err
+}
+
+// FieldError describes a cell that could not be decoded into its field.
+type FieldError struct {
+	// Row is the number of the record, starting at 1 and not counting the header.
+	Row int
+
+	// Column is the name of the column as it appears in the header.
+	Column string
+
+	// Field is the name of the struct field.
+	Field string
+
+	// Value is the content of the cell.
+	Value string
+
+	Err error
+}
+
+func (err *FieldError) Error() string {
+	return fmt.Sprintf("gocsv: row %d, column %q: %v", err.Row, err.Column, err.Err)
+}
+
+// Unwrap returns the error decoding the cell.
+func (err *FieldError) Unwrap() error {
+	return err.Err
+}
+
+// RowErrors is returned for a record that could not be decoded when the Decoder collects errors,
+// and lists the rows rejected when the Decoder has an error budget. It matches each of its
+// Errors with errors.Is and errors.As.
+type RowErrors struct {
+	// Row is the number of the record, starting at 1 and not counting the header.
+	Row int
+
+	// Record is the record as it was read.
+	Record []string
+
+	// Line is the line of the input the record starts on, counting from 1.
+	Line int
+
+	// Errors holds a *FieldError for every cell that could not be decoded, or the error that
+	// stopped decoding the record.
+	Errors []error
+}
+
+func (err *RowErrors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "gocsv: row %d: ", err.Row)
+
+	for i, e := range err.Errors {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+
+		var fieldErr *FieldError
+		if errors.As(e, &fieldErr) {
+			fmt.Fprintf(&b, "column %q: %v", fieldErr.Column, fieldErr.Err)
+		} else {
+			b.WriteString(e.Error())
+		}
+	}
+
+	return b.String()
+}
+
+// Unwrap returns Errors.
+func (err *RowErrors) Unwrap() []error {
+	return err.Errors
+}
@@ -36,3 +36,24 @@ func ExampleDecoder() {
 		println(err.Error())
 	}
 }
+
+func ExampleDecoder_DecodeAll() {
+	rdr := strings.NewReader("a,b,c\n1,2,3\n4,5,6")
+	csvrdr := csv.NewReader(rdr)
+
+	dec := gocsv.Must(gocsv.NewDecoder(csvrdr).ReadHeader())
+
+	var vals []struct {
+		A string  `csv:"a"`
+		B int     `csv:"b"`
+		C float64 `csv:"c"`
+	}
+
+	err := dec.DecodeAll(&vals)
+	if err != nil {
+		println(err.Error())
+	}
+
+	fmt.Println(len(vals))
+	// Output: 2
+}
@@ -0,0 +1,135 @@
+package gocsv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// field describes a struct field that is encoded to or decoded from a csv column.
+type field struct {
+	name       string
+	index      []int
+	tag        reflect.StructTag
+	names      []string
+	tagOptions []string
+}
+
+// fieldOptions controls how the fields of a struct are mapped to columns.
+type fieldOptions struct {
+	// tagName is the struct tag holding the column name, csv if empty.
+	tagName string
+
+	// naming, if set, names the columns of exported fields without a tag.
+	naming func(string) string
+
+	// positional binds fields to columns in declaration order instead of by name.
+	positional bool
+
+	// mappings replace the struct tags of their types.
+	mappings map[reflect.Type]*mapping
+
+	// err is returned for every type once an invalid Mapper is registered.
+	err error
+}
+
+func (opts *fieldOptions) addMapping(m *mapping) {
+	if m == nil {
+		opts.err = fmt.Errorf("%w: schema was not built with ParseSchema or LoadSchema", ErrInvalidSchema)
+		return
+	}
+
+	if opts.mappings == nil {
+		opts.mappings = map[reflect.Type]*mapping{}
+	}
+
+	opts.mappings[m.typ] = m
+}
+
+// NameExact is a naming strategy that uses the field name as the column name.
+func NameExact(name string) string {
+	return name
+}
+
+// NameSnakeCase is a naming strategy that converts a field name like UserID to user_id.
+func NameSnakeCase(name string) string {
+	return snakeCase(name)
+}
+
+// NameKebabCase is a naming strategy that converts a field name like UserID to user-id.
+func NameKebabCase(name string) string {
+	return strings.ReplaceAll(snakeCase(name), "_", "-")
+}
+
+// NameLowerCamel is a naming strategy that converts a field name like UserID to userId.
+func NameLowerCamel(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			r := []rune(word)
+			r[0] = unicode.ToUpper(r[0])
+			word = string(r)
+		}
+		words[i] = word
+	}
+
+	return strings.Join(words, "")
+}
+
+// fieldsOf returns the fields of the struct type t that are mapped to columns, in declaration
+// order, or in the order of its Mapping if one is registered.
+func (opts fieldOptions) fieldsOf(t reflect.Type) ([]field, error) {
+	if opts.err != nil {
+		return nil, opts.err
+	}
+
+	m, ok := opts.mappings[t]
+	if ok && !m.keepTags {
+		return m.fields, m.err
+	}
+
+	tagName := opts.tagName
+	if tagName == "" {
+		tagName = "csv"
+	}
+
+	var fields []field
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag, tagOptions := parseTag(f.Tag.Get(tagName))
+		if tag == "-" {
+			continue
+		}
+
+		if tagName != "csv" {
+			// Options of other tags, like json's omitempty, mean something else there.
+			_, tagOptions = parseTag(f.Tag.Get("csv"))
+		}
+
+		if tag == "" {
+			if opts.naming == nil || f.PkgPath != "" || f.Anonymous {
+				continue
+			}
+
+			tag = opts.naming(f.Name)
+		}
+
+		fields = append(fields, field{
+			name:       f.Name,
+			index:      f.Index,
+			tag:        f.Tag,
+			names:      columnNames(tag),
+			tagOptions: tagOptions,
+		})
+	}
+
+	if ok {
+		return m.mergeInto(fields)
+	}
+
+	return fields, nil
+}
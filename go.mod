@@ -1,3 +1,5 @@
 module github.com/rickbassham/gocsv
 
-go 1.13
+go 1.23
+
+require golang.org/x/text v0.22.0
@@ -0,0 +1,92 @@
+package gocsv
+
+import (
+	"strings"
+	"unicode"
+)
+
+// HeaderTrimSpace is a header normalizer that removes leading and trailing white space.
+func HeaderTrimSpace(name string) string {
+	return strings.TrimSpace(name)
+}
+
+// HeaderLower is a header normalizer that converts the name to lower case.
+func HeaderLower(name string) string {
+	return strings.ToLower(name)
+}
+
+// HeaderStripBOM is a header normalizer that removes a UTF-8 byte order mark, which some tools
+// write at the start of the first column.
+func HeaderStripBOM(name string) string {
+	return strings.TrimPrefix(name, "\ufeff")
+}
+
+// HeaderSnakeCase is a header normalizer that converts names like "Postal Code", "postalCode",
+// and "POSTAL-CODE" to "postal_code".
+func HeaderSnakeCase(name string) string {
+	return snakeCase(name)
+}
+
+// HeaderRemovePunctuation is a header normalizer that removes all punctuation characters.
+func HeaderRemovePunctuation(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return -1
+		}
+
+		return r
+	}, name)
+}
+
+func snakeCase(name string) string {
+	words := splitWords(name)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+
+	return strings.Join(words, "_")
+}
+
+// splitWords splits name into words at white space, punctuation, and changes of case, keeping
+// acronyms together so that "UserIDNumber" becomes "User", "ID", "Number".
+func splitWords(name string) []string {
+	var words []string
+	var word []rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+
+		word = append(word, r)
+	}
+	flush()
+
+	return words
+}
+
+func (dec *Decoder) normalizeHeader(name string) string {
+	for _, normalizer := range dec.headerNormalizers {
+		name = normalizer(name)
+	}
+
+	return name
+}
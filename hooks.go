@@ -0,0 +1,96 @@
+package gocsv
+
+// BeforeDecoder can be implemented by your types to inspect or rewrite the raw record before Decode
+// stores it in them.
+type BeforeDecoder interface {
+	BeforeDecodeCSV(raw []string) error
+}
+
+// AfterDecoder can be implemented by your types to normalize or enrich themselves once Decode has
+// stored a record in them.
+type AfterDecoder interface {
+	AfterDecodeCSV() error
+}
+
+// BeforeEncoder can be implemented by your types to prepare themselves before Encode writes them.
+type BeforeEncoder interface {
+	BeforeEncodeCSV() error
+}
+
+// WithBeforeDecode adds a function Decode calls with the value and the raw record before decoding,
+// after the BeforeDecodeCSV method of the value. Changes to raw are decoded.
+func (dec *Decoder) WithBeforeDecode(hook func(v interface{}, raw []string) error) *Decoder {
+	dec.beforeHooks = append(dec.beforeHooks, hook)
+	return dec
+}
+
+// WithAfterDecode adds a function Decode calls with the value once it is decoded, after the
+// AfterDecodeCSV method of the value.
+func (dec *Decoder) WithAfterDecode(hook func(v interface{}) error) *Decoder {
+	dec.afterHooks = append(dec.afterHooks, hook)
+	return dec
+}
+
+// WithBeforeEncode adds a function Encode calls with the value before encoding, after the
+// BeforeEncodeCSV method of the value.
+func (enc *Encoder) WithBeforeEncode(hook func(v interface{}) error) *Encoder {
+	enc.beforeHooks = append(enc.beforeHooks, hook)
+	return enc
+}
+
+// beforeDecode runs the hooks for v before the record raw is decoded into it.
+func (dec *Decoder) beforeDecode(v interface{}, raw []string) error {
+	if h, ok := v.(BeforeDecoder); ok {
+		err := h.BeforeDecodeCSV(raw)
+		if err != nil {
+			return &RowError{Row: dec.row, Err: err}
+		}
+	}
+
+	for _, hook := range dec.beforeHooks {
+		err := hook(v, raw)
+		if err != nil {
+			return &RowError{Row: dec.row, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// afterDecode runs the hooks for v once a record is decoded into it.
+func (dec *Decoder) afterDecode(v interface{}) error {
+	if h, ok := v.(AfterDecoder); ok {
+		err := h.AfterDecodeCSV()
+		if err != nil {
+			return &RowError{Row: dec.row, Err: err}
+		}
+	}
+
+	for _, hook := range dec.afterHooks {
+		err := hook(v)
+		if err != nil {
+			return &RowError{Row: dec.row, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// beforeEncode runs the hooks for v before it is encoded.
+func (enc *Encoder) beforeEncode(v interface{}) error {
+	if h, ok := v.(BeforeEncoder); ok {
+		err := h.BeforeEncodeCSV()
+		if err != nil {
+			return &RowError{Row: enc.row, Err: err}
+		}
+	}
+
+	for _, hook := range enc.beforeHooks {
+		err := hook(v)
+		if err != nil {
+			return &RowError{Row: enc.row, Err: err}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package gocsv_test
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rickbassham/gocsv"
+)
+
+func TestDecoder_Hooks(t *testing.T) {
+	data := strings.NewReader("JDOE,John,Doe")
+	r := csv.NewReader(data)
+
+	var calls []string
+
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"name", "first", "last"}).
+		WithBeforeDecode(func(v interface{}, raw []string) error {
+			calls = append(calls, "before "+raw[0])
+			return nil
+		}).
+		WithAfterDecode(func(v interface{}) error {
+			calls = append(calls, "after "+v.(*hookTest).FullName)
+			return nil
+		})
+
+	testVal := &hookTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.Name != "jdoe" {
+		t.Errorf("testVal.Name expected jdoe but got %s", testVal.Name)
+	}
+
+	if testVal.FullName != "John Doe" {
+		t.Errorf("testVal.FullName expected John Doe but got %s", testVal.FullName)
+	}
+
+	expected := []string{"before jdoe", "after John Doe"}
+	if strings.Join(calls, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected calls %v but got %v", expected, calls)
+	}
+}
+
+func TestDecoder_HookError(t *testing.T) {
+	data := strings.NewReader("jdoe,John,Doe\njdoe,John")
+	r := csv.NewReader(data)
+	r.FieldsPerRecord = -1
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"name", "first", "last"})
+
+	testVal := &hookTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	err = dec.Decode(testVal)
+
+	var rowErr *gocsv.RowError
+	if !errors.As(err, &rowErr) {
+		t.Errorf("expected *gocsv.RowError but got %v", err)
+		return
+	}
+
+	if rowErr.Row != 2 {
+		t.Errorf("rowErr.Row expected 2 but got %d", rowErr.Row)
+	}
+
+	if err.Error() != "gocsv: row 2: expected 3 columns" {
+		t.Errorf("expected gocsv: row 2: expected 3 columns but got %s", err.Error())
+	}
+}
+
+func TestDecoder_AfterDecodeError(t *testing.T) {
+	data := strings.NewReader("jdoe,John,Doe")
+	r := csv.NewReader(data)
+
+	hookErr := errors.New("hook failed")
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"name", "first", "last"}).
+		WithAfterDecode(func(v interface{}) error {
+			return hookErr
+		})
+
+	err := dec.Decode(&hookTest{})
+	if !errors.Is(err, hookErr) {
+		t.Errorf("expected hook failed but got %v", err)
+	}
+}
+
+func TestEncoder_Hooks(t *testing.T) {
+	val := &hookTest{Name: "jdoe", First: "John", Last: "Doe"}
+
+	expected := "JDOE,John,Doe\n"
+
+	var called interface{}
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithBeforeEncode(func(v interface{}) error {
+		called = v
+		return nil
+	})
+
+	err := enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+
+	if called != val {
+		t.Errorf("expected hook to be called with %v but got %v", val, called)
+	}
+}
+
+func TestEncoder_HookError(t *testing.T) {
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.Encode(&hookTest{})
+
+	var rowErr *gocsv.RowError
+	if !errors.As(err, &rowErr) {
+		t.Errorf("expected *gocsv.RowError but got %v", err)
+		return
+	}
+
+	if rowErr.Row != 1 {
+		t.Errorf("rowErr.Row expected 1 but got %d", rowErr.Row)
+	}
+
+	csvw.Flush()
+
+	if b.String() != "" {
+		t.Errorf("expected nothing written but got %s", b.String())
+	}
+}
@@ -0,0 +1,37 @@
+package gocsv
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// decodeJSON unmarshals value into valf, treating a nil value as a JSON null.
+func decodeJSON(valf reflect.Value, value string, isNil bool) error {
+	if isNil {
+		value = "null"
+	}
+
+	return json.Unmarshal([]byte(value), valf.Addr().Interface())
+}
+
+// encodeJSON marshals valf to compact JSON without escaping HTML characters, writing nilVal in
+// place of a JSON null.
+func encodeJSON(valf reflect.Value, nilVal string) (string, error) {
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+
+	err := enc.Encode(valf.Interface())
+	if err != nil {
+		return "", err
+	}
+
+	s := string(bytes.TrimSuffix(b.Bytes(), []byte("\n")))
+	if s == "null" {
+		return nilVal, nil
+	}
+
+	return s, nil
+}
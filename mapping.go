@@ -0,0 +1,257 @@
+package gocsv
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Mapping maps csv columns to the fields of T without struct tags, for types that cannot be
+// tagged, such as generated code or types from other packages. Build one with Map and register
+// it with Decoder.WithMapping or Encoder.WithMapping. Struct tags on T are ignored once a
+// Mapping is registered for it, unless KeepTags is used.
+type Mapping[T any] struct {
+	m *mapping
+}
+
+// Mapper is implemented by every Mapping, whatever its type parameter.
+type Mapper interface {
+	csvMapping() *mapping
+}
+
+// mapping is the type independent part of a Mapping.
+type mapping struct {
+	typ      reflect.Type
+	fields   []field
+	keepTags bool
+	err      error
+}
+
+// ColumnOption configures how a column of a Mapping is converted. Each option is equivalent to
+// a struct tag or csv tag option.
+type ColumnOption func(*columnTag)
+
+type columnTag struct {
+	tags       []string
+	tagOptions []string
+}
+
+func (c *columnTag) set(key, value string) {
+	c.tags = append(c.tags, key+":"+strconv.Quote(value))
+}
+
+// Map returns an empty Mapping for the struct type T. The zero value of Mapping is also an
+// empty Mapping.
+func Map[T any]() *Mapping[T] {
+	m := &Mapping[T]{}
+	m.init()
+	return m
+}
+
+// init builds the type independent part of the Mapping on first use.
+func (m *Mapping[T]) init() *mapping {
+	if m.m == nil {
+		m.m = &mapping{typ: reflect.TypeOf((*T)(nil)).Elem()}
+
+		if m.m.typ.Kind() != reflect.Struct {
+			m.m.err = fmt.Errorf("%w: %s is not a struct", ErrInvalidMapping, m.m.typ)
+		}
+	}
+
+	return m.m
+}
+
+// Column maps column to the field at path, a field name or a dotted path to a field of a nested
+// struct like "Price.Amount". Like a csv tag, column may list aliases separated by |. An invalid
+// path is reported by Err, and by Decode and Encode once the Mapping is registered.
+func (m *Mapping[T]) Column(column, path string, opts ...ColumnOption) *Mapping[T] {
+	m.init().column(column, path, opts)
+	return m
+}
+
+// KeepTags makes the Mapping override the struct tags of the fields it maps, instead of
+// replacing every struct tag of T.
+func (m *Mapping[T]) KeepTags() *Mapping[T] {
+	m.init().keepTags = true
+	return m
+}
+
+// Err returns the first error found while building the Mapping.
+func (m *Mapping[T]) Err() error {
+	return m.init().err
+}
+
+func (m *Mapping[T]) csvMapping() *mapping {
+	return m.init()
+}
+
+// mergeInto replaces the fields of tagged that the mapping also maps, and appends the rest.
+func (m *mapping) mergeInto(tagged []field) ([]field, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	fields := append([]field(nil), tagged...)
+
+	for _, mf := range m.fields {
+		replaced := false
+
+		for i, f := range fields {
+			if reflect.DeepEqual(f.index, mf.index) {
+				fields[i] = mf
+				replaced = true
+				break
+			}
+		}
+
+		if !replaced {
+			fields = append(fields, mf)
+		}
+	}
+
+	return fields, nil
+}
+
+func (m *mapping) column(column, path string, opts []ColumnOption) {
+	if m.err != nil {
+		return
+	}
+
+	index, err := fieldIndex(m.typ, path)
+	if err != nil {
+		m.err = err
+		return
+	}
+
+	c := &columnTag{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	m.fields = append(m.fields, field{
+		name:       path,
+		index:      index,
+		tag:        reflect.StructTag(strings.Join(c.tags, " ")),
+		names:      columnNames(column),
+		tagOptions: c.tagOptions,
+	})
+}
+
+// fieldIndex resolves a dotted path of exported field names in t, following pointers to
+// structs, to the index sequence used by fieldByIndex.
+func fieldIndex(t reflect.Type, path string) ([]int, error) {
+	var index []int
+
+	for _, name := range strings.Split(path, ".") {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%w: %s in %q is not a struct", ErrInvalidMapping, t, path)
+		}
+
+		f, ok := t.FieldByName(name)
+		if !ok || f.PkgPath != "" || len(f.Index) != 1 {
+			return nil, fmt.Errorf("%w: no exported field %s in %s", ErrInvalidMapping, name, t)
+		}
+
+		index = append(index, f.Index[0])
+		t = f.Type
+	}
+
+	return index, nil
+}
+
+// fieldByIndex returns the field of the struct v at index, following pointers to nested
+// structs. Nil pointers are allocated if alloc is true, and otherwise make fieldByIndex
+// report false.
+func fieldByIndex(v reflect.Value, index []int, alloc bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !alloc {
+					return reflect.Value{}, false
+				}
+
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		v = v.Field(x)
+	}
+
+	return v, true
+}
+
+// Format is equivalent to the format struct tag, setting the layout of a time.Time or the
+// fmt verb of a float.
+func Format(format string) ColumnOption {
+	return func(c *columnTag) { c.set("format", format) }
+}
+
+// Precision is equivalent to the precision struct tag for floats.
+func Precision(precision int) ColumnOption {
+	return func(c *columnTag) { c.set("precision", strconv.Itoa(precision)) }
+}
+
+// Base is equivalent to the base struct tag for integers.
+func Base(base int) ColumnOption {
+	return func(c *columnTag) { c.set("base", strconv.Itoa(base)) }
+}
+
+// TZ is equivalent to the tz struct tag, naming the location a time.Time is parsed in.
+func TZ(name string) ColumnOption {
+	return func(c *columnTag) { c.set("tz", name) }
+}
+
+// Encoding is equivalent to the encoding struct tag for []byte and [N]byte fields.
+func Encoding(encoding string) ColumnOption {
+	return func(c *columnTag) { c.set("encoding", encoding) }
+}
+
+// Nil is equivalent to the nil struct tag, setting the nil values of the column.
+func Nil(values ...string) ColumnOption {
+	return func(c *columnTag) { c.set("nil", strings.Join(values, ",")) }
+}
+
+// Default is equivalent to the default struct tag, giving the value decoded when the cell holds
+// a nil value or the column is missing.
+func Default(value string) ColumnOption {
+	return func(c *columnTag) { c.set("default", value) }
+}
+
+// Required is equivalent to the required csv tag option, so the column must be present even
+// with WithAllowMissingColumns.
+func Required() ColumnOption {
+	return func(c *columnTag) { c.tagOptions = append(c.tagOptions, "required") }
+}
+
+// OmitEmpty is equivalent to the omitempty csv tag option.
+func OmitEmpty() ColumnOption {
+	return func(c *columnTag) { c.tagOptions = append(c.tagOptions, "omitempty") }
+}
+
+// OmitZero is equivalent to the omitzero csv tag option.
+func OmitZero() ColumnOption {
+	return func(c *columnTag) { c.tagOptions = append(c.tagOptions, "omitzero") }
+}
+
+// JSON is equivalent to the json csv tag option.
+func JSON() ColumnOption {
+	return func(c *columnTag) { c.tagOptions = append(c.tagOptions, "json") }
+}
+
+// Normalize is equivalent to the csv tag options for each normalization in n, like trim.
+func Normalize(n Normalization) ColumnOption {
+	return func(c *columnTag) {
+		for _, option := range []string{"trim", "collapse", "nbsp", "zerowidth", "nfc"} {
+			if n&normalizationOptions[option] != 0 {
+				c.tagOptions = append(c.tagOptions, option)
+			}
+		}
+	}
+}
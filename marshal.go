@@ -0,0 +1,142 @@
+package gocsv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"reflect"
+	"sort"
+)
+
+// DecoderOption configures the Decoder used by Unmarshal and UnmarshalReader. Options that take
+// no arguments can be given as method expressions, like (*Decoder).WithAllowMissingColumns.
+type DecoderOption func(*Decoder) *Decoder
+
+// EncoderOption configures the Encoder used by Marshal and MarshalWriter. Options that take no
+// arguments can be given as method expressions, like (*Encoder).WithAllowMissingColumns.
+type EncoderOption func(*Encoder) *Encoder
+
+// Unmarshal decodes the csv in data into v. See UnmarshalReader.
+func Unmarshal(data []byte, v interface{}, opts ...DecoderOption) error {
+	return UnmarshalReader(bytes.NewReader(data), v, opts...)
+}
+
+// UnmarshalReader decodes the csv read from r into v, which may point to a slice to decode every
+// record, or to a single value to decode the first one. A *[]interface{} is a single value. The
+// first line is read as the header, unless the options give a header or every field of the
+// record type is bound by position. Empty input leaves v untouched.
+func UnmarshalReader(r io.Reader, v interface{}, opts ...DecoderOption) error {
+	dec := NewDecoder(csv.NewReader(r))
+	for _, opt := range opts {
+		dec = opt(dec)
+	}
+
+	pv := reflect.ValueOf(v)
+	slice := pv.Kind() == reflect.Ptr && !pv.IsNil() && pv.Elem().Kind() == reflect.Slice && !isDynamicSlice(v)
+
+	record := v
+	if slice {
+		t := pv.Elem().Type().Elem()
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		record = reflect.New(t).Interface()
+	}
+
+	if dec.hdr == nil && !dec.positionalOnly(record) {
+		_, err := dec.ReadHeader()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if slice {
+		return dec.DecodeAll(v)
+	}
+
+	err := dec.Decode(v)
+	if err == io.EOF {
+		return nil
+	}
+
+	return err
+}
+
+// Marshal encodes v as csv. See MarshalWriter.
+func Marshal(v interface{}, opts ...EncoderOption) ([]byte, error) {
+	var b bytes.Buffer
+
+	err := MarshalWriter(&b, v, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// MarshalWriter encodes v as csv to w, preceded by the header. v may be a slice of records, or a
+// pointer to one, or a single value, such as a struct or a pointer to one. A []interface{} is a
+// single record. A single map is written with its keys in sorted order as the header, unless the
+// options give a header.
+func MarshalWriter(w io.Writer, v interface{}, opts ...EncoderOption) error {
+	csvw := csv.NewWriter(w)
+
+	enc := NewEncoder(csvw)
+	for _, opt := range opts {
+		enc = opt(enc)
+	}
+
+	var err error
+	if reflect.Indirect(reflect.ValueOf(v)).Kind() == reflect.Slice && !isDynamicSlice(v) {
+		err = enc.EncodeAll(v)
+	} else {
+		var rec interface{}
+		rec, err = encodable(reflect.ValueOf(v))
+		if err == nil {
+			if len(enc.header) == 0 {
+				enc.mapHeader(rec)
+			}
+
+			err = enc.WithAutoHeader().Encode(rec)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	csvw.Flush()
+	return csvw.Error()
+}
+
+// mapHeader sets the header to the sorted keys of v if it is a map with string keys, or a
+// pointer to one.
+func (enc *Encoder) mapHeader(v interface{}) {
+	m := reflect.Indirect(reflect.ValueOf(v))
+	if !isStringMap(m.Type()) || m.IsNil() {
+		return
+	}
+
+	keys := make([]string, 0, m.Len())
+	for _, k := range m.MapKeys() {
+		keys = append(keys, k.String())
+	}
+
+	sort.Strings(keys)
+
+	enc.WithHeader(keys)
+}
+
+// isDynamicSlice reports whether v is a []interface{} or a *[]interface{}, which hold a single
+// record.
+func isDynamicSlice(v interface{}) bool {
+	switch v.(type) {
+	case []interface{}, *[]interface{}:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,187 @@
+package gocsv_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rickbassham/gocsv"
+)
+
+func TestUnmarshal(t *testing.T) {
+	var vals []simpleTest
+
+	err := gocsv.Unmarshal([]byte("str,n\na,1\nb,2"), &vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 2 || vals[0] != (simpleTest{"a", 1}) || vals[1] != (simpleTest{"b", 2}) {
+		t.Errorf("vals expected [{a 1 c} {b 2 d}] but got %v", vals)
+	}
+}
+
+func TestUnmarshal_Single(t *testing.T) {
+	testVal := simpleTest{}
+
+	err := gocsv.Unmarshal([]byte("str,n\na,1\nb,2"), &testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal != (simpleTest{"a", 1}) {
+		t.Errorf("testVal expected {a 1} but got %v", testVal)
+	}
+}
+
+func TestUnmarshal_Options(t *testing.T) {
+	var vals []simpleTest
+
+	err := gocsv.Unmarshal([]byte("a,1\nb,2"), &vals, (*gocsv.Decoder).WithPositionalFields)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 2 || vals[0] != (simpleTest{"a", 1}) || vals[1] != (simpleTest{"b", 2}) {
+		t.Errorf("vals expected [{a 1 c} {b 2 d}] but got %v", vals)
+	}
+}
+
+func TestUnmarshal_Empty(t *testing.T) {
+	vals := []simpleTest{{"a", 1}}
+
+	err := gocsv.Unmarshal(nil, &vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 1 {
+		t.Errorf("vals expected to be untouched but got %v", vals)
+	}
+}
+
+func TestUnmarshalReader(t *testing.T) {
+	var vals []map[string]string
+
+	err := gocsv.UnmarshalReader(strings.NewReader("a;b\n1;2"), &vals, func(dec *gocsv.Decoder) *gocsv.Decoder {
+		return dec.WithHeader([]string{"x"})
+	})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 2 || vals[0]["x"] != "a;b" || vals[1]["x"] != "1;2" {
+		t.Errorf("vals expected [map[x:a;b] map[x:1;2]] but got %v", vals)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	vals := []simpleTest{{"a", 1}, {"b", 2}}
+
+	expected := "str,n\na,1\nb,2\n"
+
+	b, err := gocsv.Marshal(vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if string(b) != expected {
+		t.Errorf("expected: %s got: %s", expected, b)
+	}
+}
+
+func TestMarshal_Single(t *testing.T) {
+	expected := "n,str\n1,a\n"
+
+	b, err := gocsv.Marshal(&simpleTest{"a", 1}, func(enc *gocsv.Encoder) *gocsv.Encoder {
+		return enc.WithHeader([]string{"n", "str"})
+	})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if string(b) != expected {
+		t.Errorf("expected: %s got: %s", expected, b)
+	}
+}
+
+func TestMarshal_StructValue(t *testing.T) {
+	expected := "str,n\na,1\n"
+
+	b, err := gocsv.Marshal(simpleTest{"a", 1})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if string(b) != expected {
+		t.Errorf("expected: %s got: %s", expected, b)
+	}
+}
+
+func TestMarshal_Map(t *testing.T) {
+	expected := "a,b,c\n1,2,3\n"
+
+	b, err := gocsv.Marshal(map[string]int{"c": 3, "a": 1, "b": 2})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if string(b) != expected {
+		t.Errorf("expected: %s got: %s", expected, b)
+	}
+}
+
+func TestMarshalWriter(t *testing.T) {
+	vals := []*simpleTest{{"a", 1}, {"b", 2}}
+
+	expected := "str,n\na,1\nb,2\n"
+
+	var b bytes.Buffer
+
+	err := gocsv.MarshalWriter(&b, &vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if b.String() != expected {
+		t.Errorf("expected: %s got: %s", expected, b.String())
+	}
+}
+
+func TestMarshal_DynamicRecord(t *testing.T) {
+	expected := "a,1\n"
+
+	b, err := gocsv.Marshal([]interface{}{"a", 1})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if string(b) != expected {
+		t.Errorf("expected: %s got: %s", expected, b)
+	}
+}
+
+func TestUnmarshal_PositionalTags(t *testing.T) {
+	var vals []positionalTest
+
+	err := gocsv.Unmarshal([]byte("a,x,1,y,c\nb,x,2,y,d\n"), &vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 2 || vals[0].A != "a" || vals[0].B != 1 || vals[1].A != "b" || vals[1].B != 2 || vals[1].C != "d" {
+		t.Errorf("vals expected [{a 1 c} {b 2 d}] but got %v", vals)
+	}
+}
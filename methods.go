@@ -0,0 +1,90 @@
+package gocsv
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var (
+	fieldUnmarshalerType = reflect.TypeOf((*FieldUnmarshaler)(nil)).Elem()
+	valueUnmarshalerType = reflect.TypeOf((*ValueUnmarshaler)(nil)).Elem()
+	fieldMarshalerType   = reflect.TypeOf((*FieldMarshaler)(nil)).Elem()
+	valueMarshallerType  = reflect.TypeOf((*ValueMarshaller)(nil)).Elem()
+	headerMarshalerType  = reflect.TypeOf((*HeaderMarshaler)(nil)).Elem()
+	timeType             = reflect.TypeOf(time.Time{})
+)
+
+// methods describes the csv methods in the method set of a type and its pointer type.
+type methods struct {
+	fieldUnmarshaler bool
+	valueUnmarshaler bool
+	fieldMarshaler   bool
+	valueMarshaller  bool
+
+	// unmarshalErr is returned when decoding into the type, because one of its unmarshalers has
+	// a value receiver that cannot modify the value it is called on.
+	unmarshalErr error
+}
+
+// methodCache holds the methods of each type, keyed by reflect.Type.
+var methodCache sync.Map
+
+// methodsOf returns the csv methods of t. The methods are called through a pointer to the
+// value, so methods with either receiver are found.
+func methodsOf(t reflect.Type) methods {
+	if m, ok := methodCache.Load(t); ok {
+		return m.(methods)
+	}
+
+	pt := reflect.PointerTo(t)
+
+	m := methods{
+		fieldUnmarshaler: pt.Implements(fieldUnmarshalerType),
+		valueUnmarshaler: pt.Implements(valueUnmarshalerType),
+		fieldMarshaler:   pt.Implements(fieldMarshalerType),
+		valueMarshaller:  pt.Implements(valueMarshallerType),
+	}
+
+	for _, iface := range []reflect.Type{fieldUnmarshalerType, valueUnmarshalerType} {
+		if t.Implements(iface) && !hasReferences(t) {
+			m.unmarshalErr = fmt.Errorf("%w: %s implements %s with a value receiver", ErrNonPointerReceiver, t, iface)
+			break
+		}
+	}
+
+	methodCache.Store(t, m)
+
+	return m
+}
+
+// hasReferences reports whether a value of type t refers to memory outside itself, so that a
+// method with a value receiver can still modify what it refers to.
+func hasReferences(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Map, reflect.Ptr, reflect.Slice, reflect.Chan, reflect.Func, reflect.Interface, reflect.UnsafePointer:
+		return true
+	case reflect.Array:
+		return hasReferences(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if hasReferences(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isTime reports whether t is time.Time or a type defined as time.Time, such as
+// `type Date time.Time`.
+func isTime(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.ConvertibleTo(timeType)
+}
+
+// invalidDestType returns ErrInvalidDestType naming the type t.
+func invalidDestType(t reflect.Type) error {
+	return fmt.Errorf("%w: %s", ErrInvalidDestType, t)
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/rickbassham/gocsv"
 )
 
 type simpleTest struct {
@@ -46,6 +49,112 @@ type valueMarshalerPointerTest struct {
 	Test      *valueMarshaller `csv:"prefixed"`
 }
 
+type fieldMarshaller string
+
+func (m *fieldMarshaller) MarshalCSVField(ctx gocsv.FieldContext) (string, error) {
+	if *m == "" {
+		return "", fmt.Errorf("%s is required", ctx.Column)
+	}
+
+	return fmt.Sprintf("%s %s:%s", ctx.Tag.Get("unit"), ctx.Column, string(*m)), nil
+}
+
+func (m *fieldMarshaller) UnmarshalCSVField(ctx gocsv.FieldContext, raw string) error {
+	*m = fieldMarshaller(fmt.Sprintf("%s:%d:%s %s", ctx.Column, ctx.Row, raw, ctx.Tag.Get("unit")))
+	return nil
+}
+
+type fieldMarshalerTest struct {
+	Weight fieldMarshaller  `csv:"weight|mass" unit:"kg"`
+	Height *fieldMarshaller `csv:"height" unit:"cm"`
+}
+
+type tagSet map[string]bool
+
+func (s tagSet) UnmarshalCSVValue(val string) error {
+	for _, tag := range strings.Split(val, ";") {
+		s[tag] = true
+	}
+	return nil
+}
+
+type valueReceiverTest struct {
+	Tags tagSet `csv:"tags"`
+}
+
+type valueReceiverString string
+
+func (s valueReceiverString) UnmarshalCSVValue(val string) error {
+	return nil
+}
+
+type valueReceiverStringTest struct {
+	A valueReceiverString `csv:"a"`
+}
+
+type pointerPointerTest struct {
+	A **int             `csv:"a"`
+	B **valueMarshaller `csv:"b"`
+}
+
+type date time.Time
+
+type namedTimeTest struct {
+	A date  `csv:"a" format:"2006-01-02"`
+	B *date `csv:"b" format:"2006-01-02"`
+}
+
+type hookTest struct {
+	Name     string `csv:"name"`
+	First    string `csv:"first"`
+	Last     string `csv:"last"`
+	FullName string
+}
+
+func (h *hookTest) BeforeDecodeCSV(raw []string) error {
+	if len(raw) != 3 {
+		return errors.New("expected 3 columns")
+	}
+
+	raw[0] = strings.ToLower(raw[0])
+	return nil
+}
+
+func (h *hookTest) AfterDecodeCSV() error {
+	h.FullName = h.First + " " + h.Last
+	return nil
+}
+
+func (h *hookTest) BeforeEncodeCSV() error {
+	if h.Name == "" {
+		return errors.New("name is required")
+	}
+
+	h.Name = strings.ToUpper(h.Name)
+	return nil
+}
+
+type atomicInner struct {
+	C string
+}
+
+type atomicTest struct {
+	A     string            `csv:"a"`
+	B     int               `csv:"b"`
+	D     map[string]string `csv:"d,json"`
+	Inner *atomicInner
+}
+
+type atomicJSON struct {
+	K int `json:"k"`
+}
+
+type atomicJSONTest struct {
+	C []int       `csv:"c,json"`
+	P *atomicJSON `csv:"p,json"`
+	N int         `csv:"n"`
+}
+
 type marshalerTest struct {
 	StringVal string `csv:"str"`
 	OtherVal  int    `csv:"n"`
@@ -81,6 +190,37 @@ func (m *mapMarshalerTest) UnmarshalCSVMap(line map[string]string) error {
 	return err
 }
 
+type headerMarshalerTest struct {
+	StringVal string
+	OtherVal  int
+}
+
+func (m *headerMarshalerTest) UnmarshalCSVRecord(hdr []string, line []string) error {
+	var err error
+
+	for i, h := range hdr {
+		switch h {
+		case "str":
+			m.StringVal = line[i]
+		case "n":
+			m.OtherVal, err = strconv.Atoi(line[i])
+		}
+	}
+
+	return err
+}
+
+func (m *headerMarshalerTest) MarshalCSVHeader() []string {
+	return []string{"str", "n"}
+}
+
+func (m *headerMarshalerTest) MarshalCSVMap() (map[string]string, error) {
+	return map[string]string{
+		"str": m.StringVal,
+		"n":   strconv.Itoa(m.OtherVal),
+	}, nil
+}
+
 type marshalerTestError struct {
 	StringVal string `csv:"str"`
 	OtherVal  int    `csv:"n"`
@@ -143,3 +283,135 @@ type invalidStructTest struct {
 		C string
 	} `csv:"a"`
 }
+
+type jsonMeta struct {
+	K int `json:"k"`
+}
+
+type jsonTest struct {
+	A jsonMeta          `csv:"a,json"`
+	B map[string]string `csv:"b,json"`
+	C []int             `csv:"c,json"`
+	D *jsonMeta         `csv:"d,json"`
+}
+
+type bytesTest struct {
+	A []byte  `csv:"a"`
+	B []byte  `csv:"b" encoding:"base64url"`
+	C [4]byte `csv:"c" encoding:"hex"`
+	D []byte  `csv:"d" encoding:"raw"`
+}
+
+type bytesTestBadEncoding struct {
+	A []byte `csv:"a" encoding:"base32"`
+}
+
+type dynamicTest struct {
+	A interface{} `csv:"a"`
+	B interface{} `csv:"b"`
+	C interface{} `csv:"c"`
+	D interface{} `csv:"d"`
+	E interface{} `csv:"e"`
+	F interface{} `csv:"f"`
+}
+
+type mapValueTest map[string]*valueMarshaller
+
+type nilTest struct {
+	A *int    `csv:"a"`
+	B *string `csv:"b"`
+	C *string `csv:"c" nil:"-"`
+	D string  `csv:"d,omitempty"`
+}
+
+type omitZeroTest struct {
+	A int     `csv:"a,omitzero"`
+	B string  `csv:"b,omitzero" nil:"N/A"`
+	C float64 `csv:"c,omitzero"`
+	D *int    `csv:"d" nil:"\\N"`
+}
+
+type nullTest struct {
+	A gocsv.Null[int]       `csv:"a"`
+	B gocsv.Null[string]    `csv:"b"`
+	C gocsv.Null[string]    `csv:"c"`
+	D gocsv.Null[time.Time] `csv:"d" format:"2006-01-02"`
+}
+
+type normalizeTest struct {
+	A int     `csv:"a,trim"`
+	B string  `csv:"b,collapse"`
+	C *int    `csv:"c,trim"`
+	D float64 `csv:"d,trim,nbsp"`
+	E string  `csv:"e,zerowidth,nfc"`
+	F string  `csv:"f"`
+}
+
+type aliasTest struct {
+	Zip  string `csv:"zip|postal_code|zip code"`
+	City string `csv:"city|town"`
+}
+
+type bindingsTest struct {
+	Email string `csv:"email"`
+	Name  string `csv:"name"`
+	Zip   string `csv:"zip|postal_code"`
+}
+
+type dbTagTest struct {
+	ID        int    `db:"id"`
+	UserName  string `db:"user_name" csv:"name"`
+	Skipped   string `db:"-"`
+	CreatedAt string
+	internal  string
+}
+
+type jsonTagTest struct {
+	Name *string `json:"name,omitempty"`
+	Note string  `json:"note" csv:",omitempty"`
+}
+
+type untaggedTest struct {
+	UserID    int `csv:"id"`
+	FirstName string
+	HTTPCode  int
+	Ignored   string `csv:"-"`
+}
+
+type mappedPrice struct {
+	Amount   float64
+	Currency string
+}
+
+type mappedTest struct {
+	SKU     string `csv:"ignored"`
+	Price   *mappedPrice
+	Created time.Time
+}
+
+type defaultTest struct {
+	ID    int    `csv:"id,required"`
+	Qty   int    `csv:"qty" default:"1"`
+	Notes string `csv:"notes" default:"none"`
+}
+
+type schemaTest struct {
+	SKU   string `csv:"item_code"`
+	Price struct {
+		Amount float64
+	}
+	Sold  gocsv.Null[time.Time]
+	Notes string `csv:"notes"`
+}
+
+type mixedPositionalTest struct {
+	A string `csv:"#0"`
+	B string `csv:"b"`
+	C string `csv:"#1"`
+}
+
+type positionalTest struct {
+	A string `csv:"#0"`
+	B int    `csv:"#2"`
+	C string `csv:"#4,omitempty"`
+}
@@ -0,0 +1,109 @@
+package gocsv
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalization is a set of transformations the Decoder applies to a cell before comparing it
+// against the nil values and converting it. Combine them with |, like
+// TrimSpace|CollapseSpace. Each can also be enabled for a single field with the csv tag
+// option given in its description, like `csv:"qty,trim"`.
+type Normalization uint
+
+const (
+	// TrimSpace removes leading and trailing white space. Tag option: trim.
+	TrimSpace Normalization = 1 << iota
+
+	// CollapseSpace replaces each run of white space inside the value with a single space.
+	// Tag option: collapse.
+	CollapseSpace
+
+	// StripNonBreakingSpace removes non-breaking spaces, such as those used as thousands
+	// separators. Tag option: nbsp.
+	StripNonBreakingSpace
+
+	// StripZeroWidth removes zero-width spaces, joiners, and byte order marks. Tag option:
+	// zerowidth.
+	StripZeroWidth
+
+	// NormalizeNFC converts the value to Unicode Normalization Form C. Tag option: nfc.
+	NormalizeNFC
+)
+
+var normalizationOptions = map[string]Normalization{
+	"trim":      TrimSpace,
+	"collapse":  CollapseSpace,
+	"nbsp":      StripNonBreakingSpace,
+	"zerowidth": StripZeroWidth,
+	"nfc":       NormalizeNFC,
+}
+
+func tagNormalization(tagOptions []string) Normalization {
+	var n Normalization
+
+	for _, option := range tagOptions {
+		n |= normalizationOptions[option]
+	}
+
+	return n
+}
+
+// apply performs every normalization in n.
+func (n Normalization) apply(value string) string {
+	if n&(StripNonBreakingSpace|StripZeroWidth) != 0 {
+		value = strings.Map(func(r rune) rune {
+			if n&StripNonBreakingSpace != 0 && isNonBreakingSpace(r) {
+				return -1
+			}
+
+			if n&StripZeroWidth != 0 && isZeroWidth(r) {
+				return -1
+			}
+
+			return r
+		}, value)
+	}
+
+	if n&TrimSpace != 0 {
+		value = strings.TrimSpace(value)
+	}
+
+	if n&CollapseSpace != 0 {
+		value = collapseSpace(value)
+	}
+
+	if n&NormalizeNFC != 0 {
+		value = norm.NFC.String(value)
+	}
+
+	return value
+}
+
+func isNonBreakingSpace(r rune) bool {
+	return r == '\u00a0' || r == '\u2007' || r == '\u202f'
+}
+
+func isZeroWidth(r rune) bool {
+	return r == '\u200b' || r == '\u200c' || r == '\u200d' || r == '\u2060' || r == '\ufeff'
+}
+
+// collapseSpace replaces each run of white space between other characters with a single
+// space, leaving leading and trailing white space alone.
+func collapseSpace(value string) string {
+	notSpace := func(r rune) bool { return !unicode.IsSpace(r) }
+
+	start := strings.IndexFunc(value, notSpace)
+	if start < 0 {
+		return value
+	}
+
+	end := strings.LastIndexFunc(value, notSpace)
+	_, size := utf8.DecodeRuneInString(value[end:])
+	end += size
+
+	return value[:start] + strings.Join(strings.Fields(value[start:end]), " ") + value[end:]
+}
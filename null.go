@@ -0,0 +1,101 @@
+package gocsv
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"reflect"
+)
+
+// Null represents a value of type T that may be null, without resorting to a pointer. The
+// Decoder sets Valid to false when a cell holds a nil value, and sets V and Valid to true
+// otherwise, so an empty cell is a valid empty value unless "" is one of the nil values. The
+// Encoder writes the nil value when Valid is false.
+//
+// Null implements json.Marshaler, json.Unmarshaler, sql.Scanner, and driver.Valuer, so it can
+// be shared with other layers of an application.
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+// NewNull returns a valid Null holding v.
+func NewNull[T any](v T) Null[T] {
+	return Null[T]{V: v, Valid: true}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (n Null[T]) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(n.V)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (n *Null[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*n = Null[T]{}
+		return nil
+	}
+
+	err := json.Unmarshal(b, &n.V)
+	if err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// Scan implements sql.Scanner.
+func (n *Null[T]) Scan(src interface{}) error {
+	s := sql.Null[T]{}
+
+	err := s.Scan(src)
+	if err != nil {
+		return err
+	}
+
+	n.V, n.Valid = s.V, s.Valid
+	return nil
+}
+
+// Value implements driver.Valuer.
+func (n Null[T]) Value() (driver.Value, error) {
+	return sql.Null[T]{V: n.V, Valid: n.Valid}.Value()
+}
+
+// csvValue returns the addressable V for the Decoder and Encoder to convert.
+func (n *Null[T]) csvValue() reflect.Value {
+	return reflect.ValueOf(&n.V).Elem()
+}
+
+func (n *Null[T]) csvValid() bool {
+	return n.Valid
+}
+
+func (n *Null[T]) setCSVValid(valid bool) {
+	if !valid {
+		var zero T
+		n.V = zero
+	}
+
+	n.Valid = valid
+}
+
+// nullable is implemented by Null so the Decoder and Encoder can handle any Null[T] without
+// knowing T.
+type nullable interface {
+	csvValue() reflect.Value
+	csvValid() bool
+	setCSVValid(bool)
+}
+
+// asNullable returns valf as a nullable if it is a Null. valf must be addressable; pointers to
+// a Null are dereferenced by the callers.
+func asNullable(valf reflect.Value) (nullable, bool) {
+	n, ok := valf.Addr().Interface().(nullable)
+	return n, ok
+}
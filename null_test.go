@@ -0,0 +1,66 @@
+package gocsv_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rickbassham/gocsv"
+)
+
+func TestNull_JSON(t *testing.T) {
+	b, err := json.Marshal([]gocsv.Null[int]{gocsv.NewNull(1), {}})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if string(b) != "[1,null]" {
+		t.Errorf("expected: [1,null] got: %s", b)
+	}
+
+	var vals []gocsv.Null[int]
+
+	err = json.Unmarshal([]byte("[2,null]"), &vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 2 || vals[0] != gocsv.NewNull(2) || vals[1].Valid {
+		t.Errorf("expected [2,null] got: %#v", vals)
+	}
+}
+
+func TestNull_Scan(t *testing.T) {
+	n := gocsv.NewNull(int64(1))
+
+	err := n.Scan(nil)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if n.Valid {
+		t.Errorf("expected null got: %#v", n)
+	}
+
+	err = n.Scan("42")
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if n != gocsv.NewNull(int64(42)) {
+		t.Errorf("expected 42 got: %#v", n)
+	}
+
+	v, err := n.Value()
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if v != int64(42) {
+		t.Errorf("expected 42 got: %#v", v)
+	}
+}
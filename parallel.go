@@ -0,0 +1,188 @@
+package gocsv
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync"
+)
+
+// WithPreserveOrder makes DecodeParallel call its function in the order the records were read.
+func (dec *Decoder) WithPreserveOrder() *Decoder {
+	dec.preserveOrder = true
+	return dec
+}
+
+// parallelRecord is a record read by DecodeParallel, along with the value decoded from it.
+type parallelRecord[T any] struct {
+	seq    int
+	row    int
+	line   int
+	record []string
+	raw    []string
+	val    *T
+	err    error
+}
+
+// DecodeParallel reads the remaining records of dec on one goroutine, decodes each into a new
+// value of type T on a pool of workers, and calls fn with the values on the calling goroutine.
+// If workers is less than 1, GOMAXPROCS workers are used. At most twice as many records as
+// workers are held in memory at once, so reading waits for fn to keep up.
+//
+// The values are passed to fn as soon as they are decoded, or in the order the records were read
+// with WithPreserveOrder. The first error from decoding, from fn, or from ctx stops the pipeline
+// and is returned once the goroutines have exited, unless the Decoder's error budget lets
+// decoding carry on past a record. Hooks run on the workers, so they must be safe for concurrent
+// use.
+func DecodeParallel[T any](ctx context.Context, dec *Decoder, workers int, fn func(*T) error) error {
+	if dec.hdr == nil && !dec.positionalOnly(new(T)) {
+		return ErrMissingHeader
+	}
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tokens := make(chan struct{}, 2*workers)
+	jobs := make(chan parallelRecord[T], workers)
+	results := make(chan parallelRecord[T], workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		// Each worker decodes with its own copy of the Decoder, so that the row number
+		// reported by errors and FieldContext is that of its record.
+		wd := *dec
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for rec := range jobs {
+				if rec.err == nil {
+					wd.row = rec.row
+					rec.val = new(T)
+					rec.err = wd.decodeRecord(rec.val, rec.record)
+				}
+
+				select {
+				case results <- rec:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	var readErr error
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+
+		for seq := 0; ; seq++ {
+			select {
+			case tokens <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			line, err := dec.r.Read()
+			start, malformed := parseError(err)
+			if err != nil && !malformed {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+
+			dec.row++
+			dec.records++
+
+			if !malformed {
+				start = dec.line()
+			}
+
+			rec := parallelRecord[T]{
+				seq:    seq,
+				row:    dec.row,
+				line:   start,
+				record: append([]string(nil), line...),
+				err:    err,
+			}
+			if dec.keepsRejected() {
+				rec.raw = append([]string(nil), line...)
+			}
+
+			select {
+			case jobs <- rec:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var err error
+
+	handle := func(rec parallelRecord[T]) {
+		<-tokens
+
+		if err != nil {
+			return
+		}
+
+		if rec.err != nil {
+			err = dec.rejectRecord(rec.row, rec.line, rec.raw, rec.err)
+		} else {
+			err = fn(rec.val)
+		}
+
+		if err != nil {
+			cancel()
+		}
+	}
+
+	next := 0
+	pending := map[int]parallelRecord[T]{}
+
+	for rec := range results {
+		if !dec.preserveOrder {
+			handle(rec)
+			continue
+		}
+
+		pending[rec.seq] = rec
+
+		for {
+			rec, ok := pending[next]
+			if !ok {
+				break
+			}
+
+			delete(pending, next)
+			next++
+
+			handle(rec)
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if readErr != nil {
+		return readErr
+	}
+
+	return ctx.Err()
+}
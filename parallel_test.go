@@ -0,0 +1,197 @@
+package gocsv_test
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/rickbassham/gocsv"
+)
+
+func parallelData(n int, bad ...int) string {
+	var b strings.Builder
+	b.WriteString("str,n\n")
+
+	for i := 0; i < n; i++ {
+		val := strconv.Itoa(i)
+		for _, j := range bad {
+			if i == j {
+				val = "x"
+			}
+		}
+
+		b.WriteString("s" + strconv.Itoa(i) + "," + val + "\n")
+	}
+
+	return b.String()
+}
+
+func TestDecodeParallel_PreserveOrder(t *testing.T) {
+	r := csv.NewReader(strings.NewReader(parallelData(100)))
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithPreserveOrder()
+
+	var vals []int
+
+	err := gocsv.DecodeParallel(context.Background(), dec, 4, func(v *simpleTest) error {
+		vals = append(vals, v.IntVal)
+		return nil
+	})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 100 {
+		t.Errorf("expected 100 records but got %d", len(vals))
+		return
+	}
+
+	for i, v := range vals {
+		if v != i {
+			t.Errorf("vals[%d] expected %d but got %d", i, i, v)
+			return
+		}
+	}
+}
+
+func TestDecodeParallel_Unordered(t *testing.T) {
+	r := csv.NewReader(strings.NewReader(parallelData(100)))
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader())
+
+	var vals []int
+
+	err := gocsv.DecodeParallel(context.Background(), dec, 0, func(v *simpleTest) error {
+		vals = append(vals, v.IntVal)
+		return nil
+	})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	sort.Ints(vals)
+
+	if len(vals) != 100 || vals[0] != 0 || vals[99] != 99 {
+		t.Errorf("expected records 0 to 99 but got %v", vals)
+	}
+}
+
+func TestDecodeParallel_DecodeError(t *testing.T) {
+	r := csv.NewReader(strings.NewReader(parallelData(100, 50)))
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithPreserveOrder()
+
+	var count int
+
+	err := gocsv.DecodeParallel(context.Background(), dec, 4, func(v *simpleTest) error {
+		count++
+		return nil
+	})
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("expected strconv.ErrSyntax but got %v", err)
+	}
+
+	if count != 50 {
+		t.Errorf("expected 50 records before the error but got %d", count)
+	}
+}
+
+func TestDecodeParallel_WithMaxErrors(t *testing.T) {
+	r := csv.NewReader(strings.NewReader(parallelData(100, 10, 20)))
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithPreserveOrder().WithMaxErrors(2)
+
+	var count int
+
+	err := gocsv.DecodeParallel(context.Background(), dec, 4, func(v *simpleTest) error {
+		count++
+		return nil
+	})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if count != 98 {
+		t.Errorf("expected 98 records but got %d", count)
+	}
+
+	report := dec.Report()
+	if len(report) != 2 || report[0].Row != 11 || report[1].Row != 21 || report[1].Line != 22 {
+		t.Errorf("expected rows 11 and 21 to be rejected but got %v", report)
+	}
+}
+
+func TestDecodeParallel_FuncError(t *testing.T) {
+	r := csv.NewReader(strings.NewReader(parallelData(1000)))
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader())
+
+	stop := errors.New("stop")
+
+	err := gocsv.DecodeParallel(context.Background(), dec, 4, func(v *simpleTest) error {
+		return stop
+	})
+	if err != stop {
+		t.Errorf("expected stop but got %v", err)
+	}
+}
+
+func TestDecodeParallel_Canceled(t *testing.T) {
+	r := csv.NewReader(strings.NewReader(parallelData(100)))
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := gocsv.DecodeParallel(ctx, dec, 4, func(v *simpleTest) error {
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled but got %v", err)
+	}
+}
+
+func TestDecodeParallel_MissingHeader(t *testing.T) {
+	r := csv.NewReader(strings.NewReader(parallelData(1)))
+	dec := gocsv.NewDecoder(r)
+
+	err := gocsv.DecodeParallel(context.Background(), dec, 4, func(v *simpleTest) error {
+		return nil
+	})
+	if err != gocsv.ErrMissingHeader {
+		t.Errorf("expected ErrMissingHeader but got %v", err)
+	}
+}
+
+func TestDecodeParallel_WithRejectSinkParseError(t *testing.T) {
+	r := csv.NewReader(strings.NewReader("str,n\na,1\nb,2,3\nc,3\n"))
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithPreserveOrder().WithMaxErrors(1).WithRejectSink(csvw)
+
+	var vals []string
+
+	err := gocsv.DecodeParallel(context.Background(), dec, 2, func(v *simpleTest) error {
+		vals = append(vals, v.StringVal)
+		return nil
+	})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if strings.Join(vals, ",") != "a,c" {
+		t.Errorf("expected records a,c but got %v", vals)
+	}
+
+	expected := "str,n,_error,_line\n" +
+		"b,2,3,gocsv: row 2: record on line 3: wrong number of fields,3\n"
+
+	if b.String() != expected {
+		t.Errorf("expected: %s got: %s", expected, b.String())
+	}
+}
@@ -0,0 +1,57 @@
+package gocsv
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// columnPosition returns the zero-based column index in a column name like "#3".
+func columnPosition(name string) (int, bool) {
+	if !strings.HasPrefix(name, "#") {
+		return 0, false
+	}
+
+	pos, err := strconv.Atoi(name[1:])
+	if err != nil || pos < 0 {
+		return 0, false
+	}
+
+	return pos, true
+}
+
+// position returns the column index that f, the i-th mapped field of its struct, is bound to
+// by position rather than by name, if any.
+func (opts fieldOptions) position(i int, f field) (int, bool) {
+	if pos, ok := columnPosition(f.names[0]); ok {
+		return pos, true
+	}
+
+	if opts.positional {
+		return i, true
+	}
+
+	return 0, false
+}
+
+// positionalOnly reports whether every field of the struct v points to is bound by position,
+// so that it can be decoded without a header.
+func (dec *Decoder) positionalOnly(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	fields, err := dec.fields.fieldsOf(t.Elem())
+	if err != nil || len(fields) == 0 {
+		return false
+	}
+
+	for i, f := range fields {
+		if _, ok := dec.fields.position(i, f); !ok {
+			return false
+		}
+	}
+
+	return true
+}
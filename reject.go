@@ -0,0 +1,39 @@
+package gocsv
+
+import (
+	"strconv"
+)
+
+// WithRejectSink makes Decode write every record that fails to decode to w, as it was read, with
+// the error and the line of the input it starts on appended in _error and _line columns. Records
+// the Reader cannot parse, reported with a *csv.ParseError, are written as far as they were read.
+// The header, with the same columns appended, is written before the first record. Combine it with
+// WithMaxErrors to carry on past the records that fail.
+func (dec *Decoder) WithRejectSink(w Writer) *Decoder {
+	dec.rejects = w
+	return dec
+}
+
+// reject writes the record of rowErrs to the reject sink.
+func (dec *Decoder) reject(rowErrs *RowErrors) error {
+	if !dec.rejectHeader && dec.header != nil {
+		hdr := append(append([]string(nil), dec.header...), "_error", "_line")
+
+		err := dec.rejects.Write(hdr)
+		if err != nil {
+			return err
+		}
+	}
+
+	dec.rejectHeader = true
+
+	record := append(append([]string(nil), rowErrs.Record...), rowErrs.Error(), strconv.Itoa(rowErrs.Line))
+
+	err := dec.rejects.Write(record)
+	if err != nil {
+		return err
+	}
+
+	dec.rejects.Flush()
+	return nil
+}
@@ -0,0 +1,134 @@
+package gocsv_test
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/rickbassham/gocsv"
+)
+
+func TestDecoder_WithRejectSink(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,x\n\"c\nd\",y\ne,5")
+	r := csv.NewReader(data)
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithMaxErrors(5).WithRejectSink(csvw)
+
+	var vals []string
+
+	for {
+		testVal := simpleTest{}
+
+		err := dec.Decode(&testVal)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Error(err.Error())
+			return
+		}
+
+		vals = append(vals, testVal.StringVal)
+	}
+
+	if strings.Join(vals, ",") != "a,e" {
+		t.Errorf("expected records a,e but got %v", vals)
+	}
+
+	expected := "str,n,_error,_line\n" +
+		"b,x,\"gocsv: row 2: strconv.ParseInt: parsing \"\"x\"\": invalid syntax\",3\n" +
+		"\"c\nd\",y,\"gocsv: row 3: strconv.ParseInt: parsing \"\"y\"\": invalid syntax\",4\n"
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestDecoder_WithRejectSinkWithoutBudget(t *testing.T) {
+	data := strings.NewReader("x,1\ny,z\nw,3")
+	r := csv.NewReader(data)
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+
+	dec := gocsv.NewDecoder(r).WithPositionalFields().WithRejectSink(csvw)
+
+	testVal := &simpleTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	err = dec.Decode(testVal)
+	if err == nil {
+		t.Error("expected error")
+		return
+	}
+
+	err = dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.StringVal != "w" || testVal.IntVal != 3 {
+		t.Errorf("testVal expected {w 3} but got %v", *testVal)
+	}
+
+	expected := "y,z,\"gocsv: row 2: strconv.ParseInt: parsing \"\"z\"\": invalid syntax\",2\n"
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestDecoder_WithRejectSinkParseError(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,2,3\nc\"d,4\ne,5")
+	r := csv.NewReader(data)
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithMaxErrors(5).WithRejectSink(csvw)
+
+	var vals []string
+
+	for {
+		testVal := simpleTest{}
+
+		err := dec.Decode(&testVal)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Error(err.Error())
+			return
+		}
+
+		vals = append(vals, testVal.StringVal)
+	}
+
+	if strings.Join(vals, ",") != "a,e" {
+		t.Errorf("expected records a,e but got %v", vals)
+	}
+
+	expected := "str,n,_error,_line\n" +
+		"b,2,3,gocsv: row 2: record on line 3: wrong number of fields,3\n" +
+		"\"gocsv: row 3: parse error on line 4, column 2: bare \"\" in non-quoted-field\",4\n"
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
@@ -0,0 +1,254 @@
+package gocsv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Schema describes how csv columns map to the fields of a struct, loaded at runtime from JSON
+// with LoadSchema or ParseSchema so column names can change without a deploy. A Schema is a
+// Mapper and is registered with Decoder.WithMapping or Encoder.WithMapping.
+//
+// An example schema:
+//
+//	{
+//	  "mode": "override",
+//	  "columns": [
+//	    {"name": "sku", "aliases": ["SKU", "item"], "field": "SKU", "type": "string", "required": true},
+//	    {"name": "price", "field": "Price.Amount", "type": "float", "precision": 2, "default": "0"},
+//	    {"name": "sold", "field": "Sold", "type": "time", "format": "2006-01-02", "nil": ["", "NULL"]}
+//	  ]
+//	}
+type Schema struct {
+	// Mode is "replace", the default, to ignore the struct tags of the target type, or
+	// "override" to keep the struct tags of fields the schema does not mention.
+	Mode string `json:"mode,omitempty"`
+
+	// Columns lists the columns, in the order the Encoder writes them when it builds the header.
+	Columns []SchemaColumn `json:"columns"`
+
+	m *mapping
+}
+
+// SchemaColumn describes one column of a Schema. Each setting is equivalent to a struct tag or
+// csv tag option.
+type SchemaColumn struct {
+	// Name is the primary name of the column.
+	Name string `json:"name"`
+
+	// Aliases are other names the column may have in the header.
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Field is the name of the struct field, or a dotted path to a field of a nested struct.
+	Field string `json:"field"`
+
+	// Type, if set, must match the field: one of string, int, uint, float, bool, time, bytes,
+	// json, or any. Pointers and Null are matched by the type they hold, and json adds the json
+	// option.
+	Type string `json:"type,omitempty"`
+
+	Format    string   `json:"format,omitempty"`
+	Precision *int     `json:"precision,omitempty"`
+	Base      *int     `json:"base,omitempty"`
+	TZ        string   `json:"tz,omitempty"`
+	Encoding  string   `json:"encoding,omitempty"`
+	Nil       []string `json:"nil,omitempty"`
+	Default   *string  `json:"default,omitempty"`
+	Required  bool     `json:"required,omitempty"`
+
+	// Options are any other csv tag options, like omitempty or trim.
+	Options []string `json:"options,omitempty"`
+}
+
+// LoadSchema reads a JSON Schema from r and validates it against the type of v, a struct or a
+// pointer to one.
+func LoadSchema(r io.Reader, v interface{}) (*Schema, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSchema(data, v)
+}
+
+// ParseSchema parses a JSON Schema and validates it against the type of v, a struct or a
+// pointer to one.
+func ParseSchema(data []byte, v interface{}) (*Schema, error) {
+	s := &Schema{}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ErrInvalidType
+	}
+
+	err = s.compile(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Schema) csvMapping() *mapping {
+	return s.m
+}
+
+func (s *Schema) compile(t reflect.Type) error {
+	m := &mapping{typ: t}
+
+	switch s.Mode {
+	case "", "replace":
+	case "override":
+		m.keepTags = true
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidSchema, s.Mode)
+	}
+
+	for _, c := range s.Columns {
+		if c.Name == "" {
+			return fmt.Errorf("%w: column for field %q has no name", ErrInvalidSchema, c.Field)
+		}
+
+		m.column(strings.Join(append([]string{c.Name}, c.Aliases...), "|"), c.Field, c.options())
+		if m.err != nil {
+			return fmt.Errorf("%w: column %q: %v", ErrInvalidSchema, c.Name, m.err)
+		}
+
+		f := m.fields[len(m.fields)-1]
+
+		err := c.validate(fieldType(t, f.index), f)
+		if err != nil {
+			return fmt.Errorf("%w: column %q: %v", ErrInvalidSchema, c.Name, err)
+		}
+	}
+
+	s.m = m
+	return nil
+}
+
+func (c SchemaColumn) options() []ColumnOption {
+	var opts []ColumnOption
+
+	if c.Format != "" {
+		opts = append(opts, Format(c.Format))
+	}
+
+	if c.Precision != nil {
+		opts = append(opts, Precision(*c.Precision))
+	}
+
+	if c.Base != nil {
+		opts = append(opts, Base(*c.Base))
+	}
+
+	if c.TZ != "" {
+		opts = append(opts, TZ(c.TZ))
+	}
+
+	if c.Encoding != "" {
+		opts = append(opts, Encoding(c.Encoding))
+	}
+
+	if c.Nil != nil {
+		opts = append(opts, Nil(c.Nil...))
+	}
+
+	if c.Default != nil {
+		opts = append(opts, Default(*c.Default))
+	}
+
+	if c.Required {
+		opts = append(opts, Required())
+	}
+
+	if c.Type == "json" {
+		opts = append(opts, JSON())
+	}
+
+	for _, option := range c.Options {
+		opts = append(opts, func(ct *columnTag) { ct.tagOptions = append(ct.tagOptions, option) })
+	}
+
+	return opts
+}
+
+// validate checks the column's type against t, the type of its field, and that its default
+// can be decoded into the field.
+func (c SchemaColumn) validate(t reflect.Type, f field) error {
+	elem := t
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	if reflect.PointerTo(elem).Implements(reflect.TypeOf((*nullable)(nil)).Elem()) {
+		elem = elem.Field(0).Type
+	}
+
+	var ok bool
+
+	switch c.Type {
+	case "", "json", "any":
+		ok = true
+	case "string":
+		ok = elem.Kind() == reflect.String
+	case "int":
+		ok = elem.Kind() >= reflect.Int && elem.Kind() <= reflect.Int64
+	case "uint":
+		ok = elem.Kind() >= reflect.Uint && elem.Kind() <= reflect.Uint64
+	case "float":
+		ok = elem.Kind() == reflect.Float32 || elem.Kind() == reflect.Float64
+	case "bool":
+		ok = elem.Kind() == reflect.Bool
+	case "time":
+		ok = isTime(elem)
+	case "bytes":
+		ok = isByteSlice(elem) || isByteArray(elem)
+	default:
+		return fmt.Errorf("unknown type %q", c.Type)
+	}
+
+	if !ok {
+		return fmt.Errorf("type %q does not match field %s of type %s", c.Type, c.Field, t)
+	}
+
+	if def, ok := f.tag.Lookup("default"); ok {
+		scratch := reflect.New(t).Elem()
+
+		err := NewDecoder(nil).decodeField(scratch, FieldContext{Field: f.name, Tag: f.tag}, f.tagOptions, def)
+		if err != nil {
+			return fmt.Errorf("invalid default %q: %v", def, err)
+		}
+	}
+
+	return nil
+}
+
+// fieldType returns the type of the field at index in the struct type t, following pointers
+// to nested structs.
+func fieldType(t reflect.Type, index []int) reflect.Type {
+	for i, x := range index {
+		if i > 0 && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		t = t.Field(x).Type
+	}
+
+	return t
+}
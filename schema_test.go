@@ -0,0 +1,160 @@
+package gocsv_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rickbassham/gocsv"
+)
+
+const testSchema = `{
+	"mode": "override",
+	"columns": [
+		{"name": "sku", "aliases": ["SKU"], "field": "SKU", "type": "string", "required": true},
+		{"name": "price", "field": "Price.Amount", "type": "float", "precision": 2, "default": "0"},
+		{"name": "sold", "field": "Sold", "type": "time", "format": "2006-01-02", "nil": ["", "NULL"]}
+	]
+}`
+
+func TestSchema_Decode(t *testing.T) {
+	s, err := gocsv.LoadSchema(strings.NewReader(testSchema), &schemaTest{})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	data := strings.NewReader("ab-1,NULL,,note\n")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"SKU", "sold", "price", "notes"}).WithMapping(s)
+
+	testVal := &schemaTest{}
+
+	err = dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.SKU != "ab-1" {
+		t.Errorf("testVal.SKU expected %s but got %s", "ab-1", testVal.SKU)
+	}
+
+	if testVal.Price.Amount != 0 {
+		t.Errorf("testVal.Price.Amount expected %f but got %f", 0.0, testVal.Price.Amount)
+	}
+
+	if testVal.Sold.Valid {
+		t.Errorf("testVal.Sold expected null but got %v", testVal.Sold)
+	}
+
+	if testVal.Notes != "note" {
+		t.Errorf("testVal.Notes expected %s but got %s", "note", testVal.Notes)
+	}
+}
+
+func TestSchema_DefaultMissingColumn(t *testing.T) {
+	schema := `{"columns": [
+		{"name": "sku", "field": "SKU", "required": true},
+		{"name": "price", "field": "Price.Amount", "default": "2.5"}
+	]}`
+
+	s, err := gocsv.ParseSchema([]byte(schema), &schemaTest{})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	data := strings.NewReader("ab-1\n")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"sku"}).WithMapping(s)
+
+	testVal := &schemaTest{}
+
+	err = dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.SKU != "ab-1" || testVal.Price.Amount != 2.5 {
+		t.Errorf("testVal expected SKU ab-1 and Price.Amount 2.5 but got %v", *testVal)
+	}
+}
+
+func TestSchema_Encode(t *testing.T) {
+	s, err := gocsv.ParseSchema([]byte(testSchema), schemaTest{})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	val := &schemaTest{
+		SKU:   "ab-1",
+		Notes: "note",
+		Sold:  gocsv.NewNull(time.Date(2019, 03, 9, 0, 0, 0, 0, time.UTC)),
+	}
+	val.Price.Amount = 9.5
+
+	expected := "ab-1,note,9.50,2019-03-09\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithMapping(s)
+
+	err = enc.Encode(val)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestSchema_Invalid(t *testing.T) {
+	schemas := []string{
+		`{"columns": [{"name": "sku", "field": "Missing"}]}`,
+		`{"columns": [{"name": "sku", "field": "SKU", "type": "int"}]}`,
+		`{"columns": [{"name": "price", "field": "Price.Amount", "default": "abc"}]}`,
+		`{"columns": [{"field": "SKU"}]}`,
+		`{"mode": "merge", "columns": []}`,
+		`{"columns": [{"name": "sku", "field": "SKU", "unknown": true}]}`,
+	}
+
+	for _, schema := range schemas {
+		_, err := gocsv.ParseSchema([]byte(schema), &schemaTest{})
+		if !errors.Is(err, gocsv.ErrInvalidSchema) {
+			t.Errorf("expected ErrInvalidSchema for %s but got %v", schema, err)
+		}
+	}
+}
+
+func TestSchema_Uncompiled(t *testing.T) {
+	s := &gocsv.Schema{Columns: []gocsv.SchemaColumn{{Name: "sku", Field: "SKU"}}}
+
+	data := strings.NewReader("ab-1\n")
+	r := csv.NewReader(data)
+	dec := gocsv.NewDecoder(r).WithHeader([]string{"sku"}).WithMapping(s)
+
+	err := dec.Decode(&schemaTest{})
+	if !errors.Is(err, gocsv.ErrInvalidSchema) {
+		t.Errorf("expected ErrInvalidSchema but got %v", err)
+	}
+
+	b := &bytes.Buffer{}
+	enc := gocsv.NewEncoder(csv.NewWriter(b)).WithMapping(s)
+
+	err = enc.Encode(&schemaTest{})
+	if !errors.Is(err, gocsv.ErrInvalidSchema) {
+		t.Errorf("expected ErrInvalidSchema but got %v", err)
+	}
+}
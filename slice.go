@@ -0,0 +1,170 @@
+package gocsv
+
+import (
+	"io"
+	"reflect"
+)
+
+// WithCapacityHint makes DecodeAll allocate room for n records up front when the slice it decodes
+// into has less capacity.
+func (dec *Decoder) WithCapacityHint(n int) *Decoder {
+	dec.capacityHint = n
+	return dec
+}
+
+// DecodeAll decodes every remaining record into the slice ptrToSlice points to, replacing its
+// contents. The elements may be of any type Decode accepts a pointer to, such as a struct or a
+// map[string]string, or pointers to structs. If a record fails to decode, the slice holds the
+// records decoded before it.
+func (dec *Decoder) DecodeAll(ptrToSlice interface{}) error {
+	pv := reflect.ValueOf(ptrToSlice)
+	if pv.Kind() != reflect.Ptr || pv.IsNil() || pv.Elem().Kind() != reflect.Slice {
+		return ErrInvalidType
+	}
+
+	sv := pv.Elem()
+	elemType := sv.Type().Elem()
+
+	s := sv.Slice(0, 0)
+	if s.Cap() < dec.capacityHint {
+		s = reflect.MakeSlice(sv.Type(), 0, dec.capacityHint)
+	}
+
+	for {
+		var elem, target reflect.Value
+
+		switch elemType.Kind() {
+		case reflect.Ptr:
+			elem = reflect.New(elemType.Elem())
+			target = elem
+		case reflect.Map:
+			elem = reflect.MakeMap(elemType)
+			target = elem
+		default:
+			target = reflect.New(elemType)
+			elem = target.Elem()
+		}
+
+		err := dec.Decode(target.Interface())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			sv.Set(s)
+			return err
+		}
+
+		s = reflect.Append(s, elem)
+	}
+
+	sv.Set(s)
+	return nil
+}
+
+// EncodeAll encodes every element of slice, which may also be a pointer to a slice, writing the
+// header before the first record. The elements may be of any type Encode accepts, or structs.
+// If the Encoder has no header, it is taken from a HeaderMarshaler or built from the struct tags
+// of the element type, or else from the first element that yields one. No header is written
+// when none of them does.
+func (enc *Encoder) EncodeAll(slice interface{}) error {
+	sv := reflect.Indirect(reflect.ValueOf(slice))
+	if sv.Kind() != reflect.Slice {
+		return ErrInvalidType
+	}
+
+	if !enc.headerWritten {
+		if len(enc.header) == 0 {
+			err := enc.elemHeader(sv)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(enc.header) > 0 {
+			err := enc.WriteHeader()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	autoHeader := enc.autoHeader
+	enc.autoHeader = true
+	defer func() { enc.autoHeader = autoHeader }()
+
+	for i := 0; i < sv.Len(); i++ {
+		v, err := encodable(sv.Index(i))
+		if err != nil {
+			return err
+		}
+
+		err = enc.Encode(v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// elemHeader sets the header from the first element of the slice sv if it is a HeaderMarshaler,
+// or else from the element type.
+func (enc *Encoder) elemHeader(sv reflect.Value) error {
+	t := sv.Type().Elem()
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if sv.Len() > 0 {
+		v, err := encodable(sv.Index(0))
+		if err != nil {
+			return err
+		}
+
+		if h, ok := v.(HeaderMarshaler); ok {
+			enc.WithHeader(h.MarshalCSVHeader())
+			return nil
+		}
+	} else if t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(headerMarshalerType) {
+		enc.WithHeader(reflect.New(t).Interface().(HeaderMarshaler).MarshalCSVHeader())
+		return nil
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	fields, err := enc.fields.fieldsOf(t)
+	if err != nil {
+		return err
+	}
+
+	enc.buildHeader(fields)
+	return nil
+}
+
+// encodable returns the element elem of a slice as a value Encode accepts, taking the address
+// of structs.
+func encodable(elem reflect.Value) (interface{}, error) {
+	if elem.Kind() == reflect.Interface {
+		elem = elem.Elem()
+	}
+
+	if !elem.IsValid() {
+		return nil, ErrInvalidType
+	}
+
+	switch elem.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+	default:
+		if !elem.CanAddr() {
+			p := reflect.New(elem.Type())
+			p.Elem().Set(elem)
+			elem = p.Elem()
+		}
+
+		elem = elem.Addr()
+	}
+
+	return elem.Interface(), nil
+}
@@ -0,0 +1,242 @@
+package gocsv_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/rickbassham/gocsv"
+)
+
+func TestDecoder_DecodeAll(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,2")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader()).WithCapacityHint(10)
+
+	var vals []simpleTest
+
+	err := dec.DecodeAll(&vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 2 || vals[0] != (simpleTest{"a", 1}) || vals[1] != (simpleTest{"b", 2}) {
+		t.Errorf("vals expected [{a 1} {b 2}] but got %v", vals)
+	}
+
+	if cap(vals) != 10 {
+		t.Errorf("cap(vals) expected 10 but got %d", cap(vals))
+	}
+}
+
+func TestDecoder_DecodeAllPointers(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,2")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader())
+
+	vals := []*simpleTest{{"old", 0}}
+
+	err := dec.DecodeAll(&vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 2 || *vals[0] != (simpleTest{"a", 1}) || *vals[1] != (simpleTest{"b", 2}) {
+		t.Errorf("vals expected [{a 1} {b 2}] but got %v", vals)
+	}
+}
+
+func TestDecoder_DecodeAllMaps(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,2")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader())
+
+	var vals []map[string]string
+
+	err := dec.DecodeAll(&vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if len(vals) != 2 || vals[0]["str"] != "a" || vals[1]["n"] != "2" {
+		t.Errorf("vals expected [map[n:1 str:a] map[n:2 str:b]] but got %v", vals)
+	}
+}
+
+func TestDecoder_DecodeAllError(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,x\nc,3")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader())
+
+	var vals []simpleTest
+
+	err := dec.DecodeAll(&vals)
+	if err == nil {
+		t.Error("expected error")
+		return
+	}
+
+	if len(vals) != 1 || vals[0] != (simpleTest{"a", 1}) {
+		t.Errorf("vals expected [{a 1}] but got %v", vals)
+	}
+}
+
+func TestDecoder_DecodeAllInvalidType(t *testing.T) {
+	data := strings.NewReader("str,n\na,1")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader())
+
+	var vals []simpleTest
+
+	err := dec.DecodeAll(vals)
+	if err != gocsv.ErrInvalidType {
+		t.Errorf("expected ErrInvalidType but got %v", err)
+	}
+}
+
+func TestEncoder_EncodeAll(t *testing.T) {
+	vals := []simpleTest{{"a", 1}, {"b", 2}}
+
+	expected := "str,n\na,1\nb,2\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.EncodeAll(vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_EncodeAllMaps(t *testing.T) {
+	vals := []map[string]string{{"str": "a", "n": "1"}, {"str": "b"}}
+
+	expected := "n,str\n1,a\n,b\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw).WithHeader([]string{"n", "str"})
+
+	err := enc.EncodeAll(&vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_EncodeAllEmpty(t *testing.T) {
+	var vals []*simpleTest
+
+	expected := "str,n\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.EncodeAll(vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_EncodeAllInterfaces(t *testing.T) {
+	vals := []interface{}{&simpleTest{"a", 1}, simpleTest{"b", 2}}
+
+	expected := "str,n\na,1\nb,2\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.EncodeAll(vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_EncodeAllMarshaler(t *testing.T) {
+	vals := []marshalerTest{{"x", 1}, {"y", 2}}
+
+	expected := "str,n\nx,1\ny,2\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.EncodeAll(vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
+
+func TestEncoder_EncodeAllHeaderMarshaler(t *testing.T) {
+	var vals []headerMarshalerTest
+
+	expected := "str,n\n"
+
+	b := strings.Builder{}
+	csvw := csv.NewWriter(&b)
+	enc := gocsv.NewEncoder(csvw)
+
+	err := enc.EncodeAll(vals)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	csvw.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}
@@ -16,9 +16,19 @@ func parseTag(tag string) (string, []string) {
 	return "", []string{}
 }
 
+// columnNames splits the name from a csv tag into the primary column name and any aliases,
+// which are separated by |, like `csv:"zip|postal_code|zip code"`.
+func columnNames(tag string) []string {
+	return strings.Split(tag, "|")
+}
+
 func omitEmpty(tagOptions []string) bool {
+	return hasOption(tagOptions, "omitempty")
+}
+
+func hasOption(tagOptions []string, name string) bool {
 	for _, option := range tagOptions {
-		if option == "omitempty" {
+		if option == name {
 			return true
 		}
 	}
@@ -39,3 +49,17 @@ func base(tag reflect.StructTag) (int, error) {
 
 	return int(base), nil
 }
+
+func isStringMap(t reflect.Type) bool {
+	return t.Kind() == reflect.Map && t.Key().Kind() == reflect.String
+}
+
+// nilValues returns the comma-separated values in the nil struct tag, if one is present.
+func nilValues(tag reflect.StructTag) ([]string, bool) {
+	nilStr, ok := tag.Lookup("nil")
+	if !ok {
+		return nil, false
+	}
+
+	return strings.Split(nilStr, ","), true
+}
@@ -0,0 +1,94 @@
+package gocsv
+
+import (
+	"io"
+	"iter"
+)
+
+// TypedDecoder decodes records into values of type T using a Decoder and its options.
+type TypedDecoder[T any] struct {
+	dec *Decoder
+	val *T
+	err error
+}
+
+// NewTypedDecoder returns a TypedDecoder reading records with dec.
+func NewTypedDecoder[T any](dec *Decoder) *TypedDecoder[T] {
+	return &TypedDecoder[T]{dec: dec}
+}
+
+// Next decodes the next record into a new value, returned by Value. It returns false at the end
+// of the input or when decoding fails, in which case Err returns the error.
+func (d *TypedDecoder[T]) Next() bool {
+	if d.err != nil {
+		return false
+	}
+
+	v := new(T)
+
+	err := d.dec.Decode(v)
+	if err != nil {
+		d.val = nil
+		if err != io.EOF {
+			d.err = err
+		}
+
+		return false
+	}
+
+	d.val = v
+	return true
+}
+
+// Value returns the value decoded by the last call to Next.
+func (d *TypedDecoder[T]) Value() *T {
+	return d.val
+}
+
+// Err returns the error that stopped Next, or nil at the end of the input.
+func (d *TypedDecoder[T]) Err() error {
+	return d.err
+}
+
+// All returns an iterator over the records read by dec, each decoded into a new value of type T.
+// The iteration stops after yielding the first error along with a nil value.
+func All[T any](dec *Decoder) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		d := NewTypedDecoder[T](dec)
+
+		for d.Next() {
+			if !yield(d.Value(), nil) {
+				return
+			}
+		}
+
+		if d.Err() != nil {
+			yield(nil, d.Err())
+		}
+	}
+}
+
+// TypedEncoder encodes values of type T using an Encoder and its options.
+type TypedEncoder[T any] struct {
+	enc *Encoder
+}
+
+// NewTypedEncoder returns a TypedEncoder writing records with enc.
+func NewTypedEncoder[T any](enc *Encoder) *TypedEncoder[T] {
+	return &TypedEncoder[T]{enc: enc}
+}
+
+// Encode writes v as a record.
+func (e *TypedEncoder[T]) Encode(v *T) error {
+	return e.enc.Encode(v)
+}
+
+// EncodeAll writes every value in vals, preceded by the header. See Encoder.EncodeAll.
+func (e *TypedEncoder[T]) EncodeAll(vals []T) error {
+	return e.enc.EncodeAll(vals)
+}
+
+// Flush will flush the underlying writer.
+func (e *TypedEncoder[T]) Flush() {
+	e.enc.Flush()
+}
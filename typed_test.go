@@ -0,0 +1,129 @@
+package gocsv_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/rickbassham/gocsv"
+)
+
+func TestTypedDecoder(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,2")
+	r := csv.NewReader(data)
+	dec := gocsv.NewTypedDecoder[simpleTest](gocsv.Must(gocsv.NewDecoder(r).ReadHeader()))
+
+	var vals []*simpleTest
+	for dec.Next() {
+		vals = append(vals, dec.Value())
+	}
+
+	if dec.Err() != nil {
+		t.Error(dec.Err().Error())
+		return
+	}
+
+	if len(vals) != 2 || *vals[0] != (simpleTest{"a", 1}) || *vals[1] != (simpleTest{"b", 2}) {
+		t.Errorf("vals expected [{a 1} {b 2}] but got %v", vals)
+	}
+}
+
+func TestTypedDecoder_Error(t *testing.T) {
+	data := strings.NewReader("str,n\na,x\nb,2")
+	r := csv.NewReader(data)
+	dec := gocsv.NewTypedDecoder[simpleTest](gocsv.Must(gocsv.NewDecoder(r).ReadHeader()))
+
+	if dec.Next() {
+		t.Error("expected Next to return false")
+	}
+
+	if dec.Err() == nil {
+		t.Error("expected error")
+	}
+
+	if dec.Next() {
+		t.Error("expected Next to keep returning false")
+	}
+}
+
+func TestAll(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,x\nc,3")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader())
+
+	var vals []string
+	var errs int
+
+	for rec, err := range gocsv.All[simpleTest](dec) {
+		if err != nil {
+			errs++
+			continue
+		}
+
+		vals = append(vals, rec.StringVal)
+	}
+
+	if strings.Join(vals, ",") != "a" {
+		t.Errorf("expected records a but got %v", vals)
+	}
+
+	if errs != 1 {
+		t.Errorf("expected 1 error but got %d", errs)
+	}
+}
+
+func TestAll_Break(t *testing.T) {
+	data := strings.NewReader("str,n\na,1\nb,2\nc,3")
+	r := csv.NewReader(data)
+	dec := gocsv.Must(gocsv.NewDecoder(r).ReadHeader())
+
+	for rec, err := range gocsv.All[simpleTest](dec) {
+		if err != nil {
+			t.Error(err.Error())
+			return
+		}
+
+		if rec.StringVal == "b" {
+			break
+		}
+	}
+
+	testVal := &simpleTest{}
+
+	err := dec.Decode(testVal)
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	if testVal.StringVal != "c" {
+		t.Errorf("testVal.StringVal expected c but got %s", testVal.StringVal)
+	}
+}
+
+func TestTypedEncoder(t *testing.T) {
+	expected := "str,n\na,1\nb,2\n"
+
+	b := strings.Builder{}
+	enc := gocsv.NewTypedEncoder[simpleTest](gocsv.NewEncoder(csv.NewWriter(&b)).WithAutoHeader())
+
+	err := enc.Encode(&simpleTest{"a", 1})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	err = enc.EncodeAll([]simpleTest{{"b", 2}})
+	if err != nil {
+		t.Error(err.Error())
+		return
+	}
+
+	enc.Flush()
+
+	actual := b.String()
+
+	if actual != expected {
+		t.Errorf("expected: %s got: %s", expected, actual)
+	}
+}